@@ -3,6 +3,8 @@ package marvel
 import (
 	"crypto/md5"
 	"encoding/hex"
+	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 )
@@ -87,3 +89,57 @@ func (csa *ClientSideAuth) Auth() *AuthParams {
 		PublicKey: csa.pubKey,
 	}
 }
+
+// AuthTransport is an http.RoundTripper which adds freshly generated AuthParams
+// to every request it sends. Server side authentication therefore receives a new
+// timestamp and hash per request, rather than once per Client.
+type AuthTransport struct {
+	Auth Authenticator
+	// Base is the underlying http.RoundTripper. If nil, http.DefaultTransport
+	// is used.
+	Base http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface. The request is cloned
+// before the authentication query parameters are added.
+func (at *AuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	authReq := req.Clone(req.Context())
+	addAuth(authReq.URL, at.Auth.Auth())
+	return at.base().RoundTrip(authReq)
+}
+
+func (at *AuthTransport) base() http.RoundTripper {
+	if at.Base != nil {
+		return at.Base
+	}
+	return http.DefaultTransport
+}
+
+// addAuth sets the authentication query parameters on the URL, replacing any
+// which are already present.
+func addAuth(u *url.URL, ap *AuthParams) {
+	q := u.Query()
+	q.Del("ts")
+	q.Del("hash")
+	if ap.Timestamp != "" {
+		q.Set("ts", ap.Timestamp)
+	}
+	q.Set("apikey", ap.PublicKey)
+	if ap.Hash != "" {
+		q.Set("hash", ap.Hash)
+	}
+	u.RawQuery = q.Encode()
+}
+
+// canonicalURL returns the URL as a string with its query parameters sorted and
+// any authentication parameters removed. It identifies a request regardless of
+// how it was authenticated.
+func canonicalURL(u *url.URL) string {
+	cu := *u
+	q := cu.Query()
+	q.Del("ts")
+	q.Del("apikey")
+	q.Del("hash")
+	cu.RawQuery = q.Encode()
+	return cu.String()
+}
@@ -1,6 +1,9 @@
 package marvel_test
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
 	"testing"
 
 	"github.com/dustinrc/marvel"
@@ -31,3 +34,36 @@ func TestClientSideAuth(t *testing.T) {
 
 	assert.Equal(t, expected, actual)
 }
+
+func TestAuthTransport(t *testing.T) {
+	var queries []map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		queries = append(queries, map[string]string{
+			"ts":     q.Get("ts"),
+			"apikey": q.Get("apikey"),
+			"hash":   q.Get("hash"),
+			"name":   q.Get("name"),
+		})
+	}))
+	defer srv.Close()
+
+	ts := 0
+	auth := marvel.NewServerSideAuth("1234", "abcd")
+	auth.Timestamper(func() string {
+		ts++
+		return strconv.Itoa(ts)
+	})
+	httpClient := &http.Client{Transport: &marvel.AuthTransport{Auth: auth}}
+
+	for i := 0; i < 2; i++ {
+		resp, err := httpClient.Get(srv.URL + "?name=Thor&ts=stale")
+		assert.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	assert.Equal(t, []map[string]string{
+		{"ts": "1", "apikey": "1234", "hash": "ffd275c5130566a2916217b101f26150", "name": "Thor"},
+		{"ts": "2", "apikey": "1234", "hash": "fb3e9adaca5a4ba3b717c5b5f5ed9aaf", "name": "Thor"},
+	}, queries)
+}
@@ -0,0 +1,53 @@
+package marvel
+
+import (
+	"context"
+	"sync"
+)
+
+// getMany calls get once for each distinct ID, at most workers at a time, and
+// collects the results and errors by ID. The API cannot filter a resource
+// by its own IDs, so there is no list query to collapse the requests into.
+func getMany(ctx context.Context, ids []int, workers int, get func(context.Context, int) (interface{}, error)) (map[int]interface{}, map[int]error) {
+	results := make(map[int]interface{}, len(ids))
+	errs := make(map[int]error)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	if workers < 1 {
+		workers = 1
+	}
+	sem := make(chan struct{}, workers)
+	seen := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			mu.Lock()
+			errs[id] = ctx.Err()
+			mu.Unlock()
+			continue
+		}
+		wg.Add(1)
+		go func(id int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			v, err := get(ctx, id)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[id] = err
+				return
+			}
+			results[id] = v
+		}(id)
+	}
+	wg.Wait()
+	return results, errs
+}
@@ -0,0 +1,111 @@
+package marvel_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dustinrc/marvel"
+	"github.com/stretchr/testify/assert"
+)
+
+// batchHandler serves a single entity with the requested ID, or a 404 for IDs
+// over 1000, recording how many times each ID was requested.
+type batchHandler struct {
+	mu       sync.Mutex
+	requests map[int]int
+	inFlight int32
+	peak     int32
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (bh *batchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n := atomic.AddInt32(&bh.inFlight, 1)
+	defer atomic.AddInt32(&bh.inFlight, -1)
+	for {
+		peak := atomic.LoadInt32(&bh.peak)
+		if n <= peak || atomic.CompareAndSwapInt32(&bh.peak, peak, n) {
+			break
+		}
+	}
+	id, _ := strconv.Atoi(r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+	bh.mu.Lock()
+	bh.requests[id]++
+	bh.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+	if id > 1000 {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"code": 404, "status": "We couldn't find that entity"}`)
+		return
+	}
+	fmt.Fprintf(w, `{"code": 200, "data": {"results": [{"id": %d}]}}`, id)
+}
+
+func TestGetMany(t *testing.T) {
+	bh := &batchHandler{requests: make(map[int]int)}
+	c := newServerClient(t, &mockAuth{}, bh)
+
+	ids := []int{1001}
+	for id := 1; id <= 40; id++ {
+		ids = append(ids, id)
+	}
+	ids = append(ids, 1, 2, 3, 1002)
+
+	comics, errs := c.Comics.GetMany(ids, 8)
+	assert.Len(t, comics, 40)
+	for id, comic := range comics {
+		assert.Equal(t, id, comic.ID)
+	}
+	assert.Len(t, errs, 2)
+	for _, id := range []int{1001, 1002} {
+		assert.True(t, errors.Is(errs[id], marvel.ErrNotFound), "ID %d: %v", id, errs[id])
+		assert.NotContains(t, comics, id)
+	}
+	for id, count := range bh.requests {
+		assert.Equal(t, 1, count, "ID %d requested more than once", id)
+	}
+	assert.True(t, bh.peak > 1, "Requests were not concurrent")
+	assert.True(t, bh.peak <= 8, "More than 8 requests in flight at once")
+
+	t.Run("every service", func(t *testing.T) {
+		ids := []int{1, 2, 1001}
+		characters, errs := c.Characters.GetMany(ids, 2)
+		assert.Equal(t, []int{2, 1}, []int{len(characters), len(errs)})
+		creators, errs := c.Creators.GetMany(ids, 2)
+		assert.Equal(t, []int{2, 1}, []int{len(creators), len(errs)})
+		events, errs := c.Events.GetMany(ids, 2)
+		assert.Equal(t, []int{2, 1}, []int{len(events), len(errs)})
+		series, errs := c.Series.GetMany(ids, 2)
+		assert.Equal(t, []int{2, 1}, []int{len(series), len(errs)})
+		stories, errs := c.Stories.GetMany(ids, 2)
+		assert.Equal(t, []int{2, 1}, []int{len(stories), len(errs)})
+	})
+	t.Run("worker count", func(t *testing.T) {
+		for _, workers := range []int{0, 3} {
+			bh := &batchHandler{requests: make(map[int]int)}
+			c := newServerClient(t, &mockAuth{}, bh)
+			comics, errs := c.Comics.GetMany(ids, workers)
+			assert.Len(t, comics, 40)
+			assert.Len(t, errs, 2)
+			if workers < 1 {
+				workers = 1
+			}
+			assert.True(t, int(bh.peak) <= workers, "More than %d requests in flight at once", workers)
+		}
+	})
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		comics, errs := c.Comics.GetManyContext(ctx, []int{1, 2, 3}, 2)
+		assert.Empty(t, comics)
+		assert.Len(t, errs, 3)
+	})
+}
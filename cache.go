@@ -0,0 +1,406 @@
+package marvel
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cachedHeader marks responses whose body was served from a Cache.
+const cachedHeader = "X-Marvel-Cache"
+
+// Cached reports whether the response's body was served from the Client's Cache
+// rather than the API.
+func Cached(resp *http.Response) bool {
+	return resp != nil && resp.Header.Get(cachedHeader) != ""
+}
+
+// Resource names a top level collection of the API, as used in request paths.
+type Resource string
+
+// The API's top level collections.
+const (
+	CharactersResource Resource = "characters"
+	ComicsResource     Resource = "comics"
+	CreatorsResource   Resource = "creators"
+	EventsResource     Resource = "events"
+	SeriesResource     Resource = "series"
+	StoriesResource    Resource = "stories"
+)
+
+// Cache is the interface for storing the raw JSON bodies of API responses. Keys
+// are request URLs without authentication parameters. Implementations must be
+// safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored for key, if it has not expired.
+	Get(key string) ([]byte, bool)
+	// Set stores value for key until ttl has elapsed.
+	Set(key string, value []byte, ttl time.Duration)
+	// Delete removes the value stored for key.
+	Delete(key string)
+	// DeletePrefix removes every value whose key begins with prefix.
+	DeletePrefix(prefix string)
+}
+
+// lru is a map which evicts its least recently used value once its capacity
+// is reached. It is not safe for concurrent use.
+type lru struct {
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+// lruEntry is a value held by an lru.
+type lruEntry struct {
+	key   string
+	value interface{}
+}
+
+// newLRU returns an lru holding at most capacity values. A capacity less than
+// 1 is treated as 1.
+func newLRU(capacity int) *lru {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &lru{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// get returns the value stored for key, marking it most recently used.
+func (l *lru) get(key string) (interface{}, bool) {
+	elem, ok := l.entries[key]
+	if !ok {
+		return nil, false
+	}
+	l.order.MoveToFront(elem)
+	return elem.Value.(*lruEntry).value, true
+}
+
+// add stores value for key, evicting the least recently used value if the
+// capacity is exceeded.
+func (l *lru) add(key string, value interface{}) {
+	if elem, ok := l.entries[key]; ok {
+		elem.Value = &lruEntry{key: key, value: value}
+		l.order.MoveToFront(elem)
+		return
+	}
+	l.entries[key] = l.order.PushFront(&lruEntry{key: key, value: value})
+	for l.order.Len() > l.capacity {
+		l.remove(l.order.Back().Value.(*lruEntry).key)
+	}
+}
+
+// remove deletes the value stored for key.
+func (l *lru) remove(key string) {
+	if elem, ok := l.entries[key]; ok {
+		l.order.Remove(elem)
+		delete(l.entries, key)
+	}
+}
+
+// memoryEntry is a value held by a MemoryCache.
+type memoryEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+// MemoryCache is an in-memory Cache which evicts the least recently used value
+// once its capacity is reached.
+type MemoryCache struct {
+	mu      sync.Mutex
+	entries *lru
+}
+
+// NewMemoryCache returns a MemoryCache holding at most capacity values. A
+// capacity less than 1 is treated as 1.
+func NewMemoryCache(capacity int) *MemoryCache {
+	return &MemoryCache{entries: newLRU(capacity)}
+}
+
+// Get implements the Cache interface.
+func (mc *MemoryCache) Get(key string) ([]byte, bool) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	v, ok := mc.entries.get(key)
+	if !ok {
+		return nil, false
+	}
+	entry := v.(*memoryEntry)
+	if time.Now().After(entry.expires) {
+		mc.entries.remove(key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// Set implements the Cache interface.
+func (mc *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	mc.entries.add(key, &memoryEntry{value: value, expires: time.Now().Add(ttl)})
+}
+
+// Delete implements the Cache interface.
+func (mc *MemoryCache) Delete(key string) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	mc.entries.remove(key)
+}
+
+// DeletePrefix implements the Cache interface.
+func (mc *MemoryCache) DeletePrefix(prefix string) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	for key := range mc.entries.entries {
+		if strings.HasPrefix(key, prefix) {
+			mc.entries.remove(key)
+		}
+	}
+}
+
+// Len returns the number of values held, including any which have expired but
+// not yet been removed.
+func (mc *MemoryCache) Len() int {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+	return mc.entries.order.Len()
+}
+
+// fileMeta describes a value held by a FileCache.
+type fileMeta struct {
+	Key     string    `json:"key"`
+	Expires time.Time `json:"expires"`
+}
+
+// FileCache is a Cache which stores each value in a directory as a raw JSON file,
+// named by the hash of its key, alongside a small metadata file.
+type FileCache struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewFileCache returns a FileCache storing values in dir, which is created if
+// necessary.
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileCache{dir: dir}, nil
+}
+
+// Get implements the Cache interface.
+func (fc *FileCache) Get(key string) ([]byte, bool) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	base := fc.base(key)
+	meta, err := readFileMeta(base + ".meta")
+	if err != nil || meta.Key != key {
+		return nil, false
+	}
+	if time.Now().After(meta.Expires) {
+		fc.remove(base)
+		return nil, false
+	}
+	value, err := ioutil.ReadFile(base + ".json")
+	if err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// Set implements the Cache interface. Errors writing the files are ignored,
+// leaving the value uncached.
+func (fc *FileCache) Set(key string, value []byte, ttl time.Duration) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	base := fc.base(key)
+	meta, err := json.Marshal(&fileMeta{Key: key, Expires: time.Now().Add(ttl)})
+	if err != nil {
+		return
+	}
+	if writeFileAtomic(base+".json", value) != nil || writeFileAtomic(base+".meta", meta) != nil {
+		fc.remove(base)
+	}
+}
+
+// Delete implements the Cache interface.
+func (fc *FileCache) Delete(key string) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.remove(fc.base(key))
+}
+
+// DeletePrefix implements the Cache interface.
+func (fc *FileCache) DeletePrefix(prefix string) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	metaPaths, err := filepath.Glob(filepath.Join(fc.dir, "*.meta"))
+	if err != nil {
+		return
+	}
+	for _, metaPath := range metaPaths {
+		meta, err := readFileMeta(metaPath)
+		if err == nil && strings.HasPrefix(meta.Key, prefix) {
+			fc.remove(strings.TrimSuffix(metaPath, ".meta"))
+		}
+	}
+}
+
+// base returns the path, less extension, of the files holding key's value.
+func (fc *FileCache) base(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(fc.dir, hex.EncodeToString(sum[:]))
+}
+
+// remove deletes the files of a value.
+func (fc *FileCache) remove(base string) {
+	os.Remove(base + ".meta")
+	os.Remove(base + ".json")
+}
+
+// readFileMeta reads the metadata file at path.
+func readFileMeta(path string) (*fileMeta, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	meta := &fileMeta{}
+	return meta, json.Unmarshal(b, meta)
+}
+
+// writeFileAtomic writes data to a temporary file which is then renamed to path,
+// so readers never see a partial file.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// cacheTransport is an http.RoundTripper which answers GET requests from a Cache
+// when possible, and stores successful responses in it.
+type cacheTransport struct {
+	mu    sync.Mutex
+	cache Cache
+	ttl   time.Duration
+	ttls  map[Resource]time.Duration
+	base  http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (ct *cacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ct.mu.Lock()
+	cache, ttl := ct.cache, ct.ttl
+	if resTTL, ok := ct.ttls[resourceOf(req.URL.Path)]; ok {
+		ttl = resTTL
+	}
+	ct.mu.Unlock()
+	if cache == nil || ttl <= 0 || req.Method != http.MethodGet {
+		return ct.base.RoundTrip(req)
+	}
+
+	key := canonicalURL(req.URL)
+	if body, ok := cache.Get(key); ok {
+		return cachedResponse(req, body), nil
+	}
+	resp, err := ct.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	cache.Set(key, body, ttl)
+	return resp, nil
+}
+
+// setCache replaces the Cache and its default TTL.
+func (ct *cacheTransport) setCache(cache Cache, ttl time.Duration) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	ct.cache = cache
+	ct.ttl = ttl
+}
+
+// setTTL overrides the default TTL for the resource.
+func (ct *cacheTransport) setTTL(resource Resource, ttl time.Duration) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	if ct.ttls == nil {
+		ct.ttls = make(map[Resource]time.Duration)
+	}
+	ct.ttls[resource] = ttl
+}
+
+// invalidate removes the cached responses for the entity URL, with or without
+// query parameters, and for its sub-resources.
+func (ct *cacheTransport) invalidate(entityURL string) {
+	ct.mu.Lock()
+	cache := ct.cache
+	ct.mu.Unlock()
+	if cache == nil {
+		return
+	}
+	cache.Delete(entityURL)
+	cache.DeletePrefix(entityURL + "?")
+	cache.DeletePrefix(entityURL + "/")
+}
+
+// resourceOf returns the top level collection named in an API request path,
+// i.e., the first path segment which names one.
+func resourceOf(path string) Resource {
+	for _, segment := range strings.Split(path, "/") {
+		switch res := Resource(segment); res {
+		case CharactersResource, ComicsResource, CreatorsResource,
+			EventsResource, SeriesResource, StoriesResource:
+			return res
+		}
+	}
+	return ""
+}
+
+// cachedResponse returns a new 200 OK response holding body, marked as cached.
+func cachedResponse(req *http.Request, body []byte) *http.Response {
+	header := make(http.Header)
+	header.Set("Content-Type", "application/json; charset=utf-8")
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	header.Set(cachedHeader, "hit")
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
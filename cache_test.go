@@ -0,0 +1,138 @@
+package marvel_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dustinrc/marvel"
+	"github.com/stretchr/testify/assert"
+)
+
+func testCache(t *testing.T, cache marvel.Cache) {
+	t.Run("values are returned until they expire", func(t *testing.T) {
+		cache.Set("a", []byte(`{"code": 200}`), time.Hour)
+		cache.Set("b", []byte(`{"code": 201}`), time.Millisecond)
+		time.Sleep(5 * time.Millisecond)
+
+		value, ok := cache.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, `{"code": 200}`, string(value))
+		_, ok = cache.Get("b")
+		assert.False(t, ok, "Expired value returned")
+		_, ok = cache.Get("c")
+		assert.False(t, ok, "Missing value returned")
+	})
+	t.Run("values are replaced", func(t *testing.T) {
+		cache.Set("a", []byte(`{"code": 202}`), time.Hour)
+
+		value, ok := cache.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, `{"code": 202}`, string(value))
+	})
+	t.Run("values are deleted", func(t *testing.T) {
+		cache.Set("d", []byte(`{"code": 203}`), time.Hour)
+		cache.Delete("d")
+
+		_, ok := cache.Get("d")
+		assert.False(t, ok, "Deleted value returned")
+	})
+	t.Run("values are deleted by prefix", func(t *testing.T) {
+		cache.Set("comics/1?limit=1", []byte(`1`), time.Hour)
+		cache.Set("comics/1/stories", []byte(`2`), time.Hour)
+		cache.Set("comics/10", []byte(`3`), time.Hour)
+		cache.DeletePrefix("comics/1/")
+
+		_, ok := cache.Get("comics/1?limit=1")
+		assert.True(t, ok)
+		_, ok = cache.Get("comics/1/stories")
+		assert.False(t, ok, "Deleted value returned")
+		_, ok = cache.Get("comics/10")
+		assert.True(t, ok)
+	})
+}
+
+func TestMemoryCache(t *testing.T) {
+	testCache(t, marvel.NewMemoryCache(10))
+
+	t.Run("least recently used values are evicted", func(t *testing.T) {
+		mc := marvel.NewMemoryCache(2)
+		mc.Set("a", []byte(`1`), time.Hour)
+		mc.Set("b", []byte(`2`), time.Hour)
+		mc.Get("a")
+		mc.Set("c", []byte(`3`), time.Hour)
+
+		assert.Equal(t, 2, mc.Len())
+		_, ok := mc.Get("a")
+		assert.True(t, ok)
+		_, ok = mc.Get("b")
+		assert.False(t, ok, "Least recently used value not evicted")
+		_, ok = mc.Get("c")
+		assert.True(t, ok)
+	})
+}
+
+func TestFileCache(t *testing.T) {
+	dir := t.TempDir()
+	fc, err := marvel.NewFileCache(dir)
+	assert.NoError(t, err)
+	testCache(t, fc)
+
+	t.Run("values persist between instances", func(t *testing.T) {
+		fc.Set("persisted", []byte(`{"code": 200}`), time.Hour)
+
+		other, err := marvel.NewFileCache(dir)
+		assert.NoError(t, err)
+		value, ok := other.Get("persisted")
+		assert.True(t, ok)
+		assert.Equal(t, `{"code": 200}`, string(value))
+	})
+}
+
+func TestClientCache(t *testing.T) {
+	requests := 0
+	c := newServerClient(t, &mockAuth{},
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			fmt.Fprintf(w, `{"code": 200, "data": {"results": [{"id": %d}]}}`, requests)
+		}))
+	c.Cache(marvel.NewMemoryCache(100), time.Hour)
+	c.CacheTTL(marvel.StoriesResource, 0)
+
+	t.Run("responses are cached", func(t *testing.T) {
+		wrap, resp, err := c.Characters.GetWrapped(1009610)
+		assert.NoError(t, err)
+		assert.False(t, marvel.Cached(resp))
+		assert.Equal(t, 1, wrap.Data.Results[0].ID)
+
+		wrap, resp, err = c.Characters.GetWrapped(1009610)
+		assert.NoError(t, err)
+		assert.True(t, marvel.Cached(resp), "Response not served from cache")
+		assert.Equal(t, 1, wrap.Data.Results[0].ID)
+		assert.Equal(t, 1, requests)
+	})
+	t.Run("query parameters are part of the key", func(t *testing.T) {
+		c.Characters.ComicsWrapped(1009610, &marvel.ComicParams{Title: "a"})
+		_, resp, _ := c.Characters.ComicsWrapped(1009610, &marvel.ComicParams{Title: "b"})
+		assert.False(t, marvel.Cached(resp))
+		_, resp, _ = c.Characters.ComicsWrapped(1009610, &marvel.ComicParams{Title: "a"})
+		assert.True(t, marvel.Cached(resp))
+	})
+	t.Run("resources may be excluded", func(t *testing.T) {
+		c.Stories.Get(1)
+		_, resp, _ := c.Stories.GetWrapped(1)
+		assert.False(t, marvel.Cached(resp))
+	})
+	t.Run("entities are invalidated by ID", func(t *testing.T) {
+		c.Characters.Get(1)
+		c.InvalidateCache(marvel.CharactersResource, 1009610)
+
+		_, resp, _ := c.Characters.GetWrapped(1009610)
+		assert.False(t, marvel.Cached(resp), "Invalidated entity served from cache")
+		_, resp, _ = c.Characters.ComicsWrapped(1009610, &marvel.ComicParams{Title: "b"})
+		assert.False(t, marvel.Cached(resp), "Invalidated sub-resource served from cache")
+		_, resp, _ = c.Characters.GetWrapped(1)
+		assert.True(t, marvel.Cached(resp), "Other entity invalidated")
+	})
+}
@@ -1,6 +1,7 @@
 package marvel
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -23,92 +24,266 @@ func NewCharacterService(sling *sling.Sling) *CharacterService {
 // AllWrapped returns all characters that match the query parameters. The character
 // slice will be encapsulated by CharacterDataContainer and CharacterDataWrapper.
 func (chs *CharacterService) AllWrapped(params *CharacterParams) (*CharacterDataWrapper, *http.Response, error) {
+	return chs.AllWrappedContext(context.Background(), params)
+}
+
+// AllWrappedContext is like AllWrapped, but the request is sent using ctx.
+func (chs *CharacterService) AllWrappedContext(ctx context.Context, params *CharacterParams) (*CharacterDataWrapper, *http.Response, error) {
 	wrap := &CharacterDataWrapper{}
-	resp, err := receiveWrapped(chs.sling, "../characters", wrap, params)
+	resp, err := receiveWrapped(ctx, chs.sling, "../characters", wrap, params)
 	return wrap, resp, err
 }
 
 // All returns all characters that match the query parameters.
 func (chs *CharacterService) All(params *CharacterParams) ([]Character, error) {
-	wrap, _, err := chs.AllWrapped(params)
-	return wrap.Data.Results, err
+	return chs.AllContext(context.Background(), params)
+}
+
+// AllContext is like All, but the request is sent using ctx.
+func (chs *CharacterService) AllContext(ctx context.Context, params *CharacterParams) ([]Character, error) {
+	wrap, _, err := chs.AllWrappedContext(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
 }
 
 // GetWrapped returns the character associated with the given ID. The character
 // details will be encapsulated by CharacterDataContainer and CharacterDataWrapper.
 func (chs *CharacterService) GetWrapped(characterID int) (*CharacterDataWrapper, *http.Response, error) {
+	return chs.GetWrappedContext(context.Background(), characterID)
+}
+
+// GetWrappedContext is like GetWrapped, but the request is sent using ctx.
+func (chs *CharacterService) GetWrappedContext(ctx context.Context, characterID int) (*CharacterDataWrapper, *http.Response, error) {
 	wrap := &CharacterDataWrapper{}
-	resp, err := receiveWrapped(chs.sling, fmt.Sprintf("%d", characterID), wrap, nil)
+	resp, err := receiveWrapped(ctx, chs.sling, fmt.Sprintf("%d", characterID), wrap, nil)
 	return wrap, resp, err
 }
 
-// Get returns the character associated with the given ID.
+// Get returns the character associated with the given ID. A NotFoundError is
+// returned if there is no such character.
 func (chs *CharacterService) Get(characterID int) (*Character, error) {
-	wrap, _, err := chs.GetWrapped(characterID)
-	if err != nil {
+	return chs.GetContext(context.Background(), characterID)
+}
+
+// GetContext is like Get, but the request is sent using ctx.
+func (chs *CharacterService) GetContext(ctx context.Context, characterID int) (*Character, error) {
+	wrap, _, err := chs.GetWrappedContext(ctx, characterID)
+	if err = getError(CharactersResource, characterID, err, len(wrap.Data.Results)); err != nil {
 		return nil, err
 	}
 	return &wrap.Data.Results[0], nil
 }
 
+// GetMany returns the characters associated with the given IDs, keyed by ID.
+// The characters are requested concurrently, at most workers at a time, and any
+// errors are returned keyed by the ID that caused them. A workers value less
+// than 1 is treated as 1.
+func (chs *CharacterService) GetMany(characterIDs []int, workers int) (map[int]*Character, map[int]error) {
+	return chs.GetManyContext(context.Background(), characterIDs, workers)
+}
+
+// GetManyContext is like GetMany, but the requests are sent using ctx.
+func (chs *CharacterService) GetManyContext(ctx context.Context, characterIDs []int, workers int) (map[int]*Character, map[int]error) {
+	values, errs := getMany(ctx, characterIDs, workers, func(ctx context.Context, id int) (interface{}, error) {
+		return chs.GetContext(ctx, id)
+	})
+	characters := make(map[int]*Character, len(values))
+	for id, v := range values {
+		characters[id] = v.(*Character)
+	}
+	return characters, errs
+}
+
 // ComicsWrapped returns all comics involving the given character and match the
 // query parameters. The comic slice will be encapsulated by ComicDataContainer
 // and ComicDataWrapper.
 func (chs *CharacterService) ComicsWrapped(characterID int, params *ComicParams) (*ComicDataWrapper, *http.Response, error) {
+	return chs.ComicsWrappedContext(context.Background(), characterID, params)
+}
+
+// ComicsWrappedContext is like ComicsWrapped, but the request is sent using ctx.
+func (chs *CharacterService) ComicsWrappedContext(ctx context.Context, characterID int, params *ComicParams) (*ComicDataWrapper, *http.Response, error) {
 	wrap := &ComicDataWrapper{}
-	resp, err := receiveWrapped(chs.sling, fmt.Sprintf("%d/comics", characterID), wrap, params)
+	resp, err := receiveWrapped(ctx, chs.sling, fmt.Sprintf("%d/comics", characterID), wrap, params)
 	return wrap, resp, err
 }
 
 // Comics returns all comics involving the given character and match the query parameters.
 func (chs *CharacterService) Comics(characterID int, params *ComicParams) ([]Comic, error) {
-	wrap, _, err := chs.ComicsWrapped(characterID, params)
-	return wrap.Data.Results, err
+	return chs.ComicsContext(context.Background(), characterID, params)
+}
+
+// ComicsContext is like Comics, but the request is sent using ctx.
+func (chs *CharacterService) ComicsContext(ctx context.Context, characterID int, params *ComicParams) ([]Comic, error) {
+	wrap, _, err := chs.ComicsWrappedContext(ctx, characterID, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
 }
 
 // EventsWrapped returns all events involving the given character and match the
 // query parameters. The event slice will be encapsulated by EventDataContainer
 // and EventDataWrapper.
 func (chs *CharacterService) EventsWrapped(characterID int, params *EventParams) (*EventDataWrapper, *http.Response, error) {
+	return chs.EventsWrappedContext(context.Background(), characterID, params)
+}
+
+// EventsWrappedContext is like EventsWrapped, but the request is sent using ctx.
+func (chs *CharacterService) EventsWrappedContext(ctx context.Context, characterID int, params *EventParams) (*EventDataWrapper, *http.Response, error) {
 	wrap := &EventDataWrapper{}
-	resp, err := receiveWrapped(chs.sling, fmt.Sprintf("%d/events", characterID), wrap, params)
+	resp, err := receiveWrapped(ctx, chs.sling, fmt.Sprintf("%d/events", characterID), wrap, params)
 	return wrap, resp, err
 }
 
 // Events returns all events involving the given character and match the query parameters.
 func (chs *CharacterService) Events(characterID int, params *EventParams) ([]Event, error) {
-	wrap, _, err := chs.EventsWrapped(characterID, params)
-	return wrap.Data.Results, err
+	return chs.EventsContext(context.Background(), characterID, params)
+}
+
+// EventsContext is like Events, but the request is sent using ctx.
+func (chs *CharacterService) EventsContext(ctx context.Context, characterID int, params *EventParams) ([]Event, error) {
+	wrap, _, err := chs.EventsWrappedContext(ctx, characterID, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
 }
 
 // SeriesWrapped returns all series involving the given character and match the
 // query parameters. The series slice will be encapsulated by SeriesDataContainer
 // and SeriesDataWrapper.
 func (chs *CharacterService) SeriesWrapped(characterID int, params *SeriesParams) (*SeriesDataWrapper, *http.Response, error) {
+	return chs.SeriesWrappedContext(context.Background(), characterID, params)
+}
+
+// SeriesWrappedContext is like SeriesWrapped, but the request is sent using ctx.
+func (chs *CharacterService) SeriesWrappedContext(ctx context.Context, characterID int, params *SeriesParams) (*SeriesDataWrapper, *http.Response, error) {
 	wrap := &SeriesDataWrapper{}
-	resp, err := receiveWrapped(chs.sling, fmt.Sprintf("%d/series", characterID), wrap, params)
+	resp, err := receiveWrapped(ctx, chs.sling, fmt.Sprintf("%d/series", characterID), wrap, params)
 	return wrap, resp, err
 }
 
 // Series returns all series involving the given character and match the query parameters.
 func (chs *CharacterService) Series(characterID int, params *SeriesParams) ([]Series, error) {
-	wrap, _, err := chs.SeriesWrapped(characterID, params)
-	return wrap.Data.Results, err
+	return chs.SeriesContext(context.Background(), characterID, params)
+}
+
+// SeriesContext is like Series, but the request is sent using ctx.
+func (chs *CharacterService) SeriesContext(ctx context.Context, characterID int, params *SeriesParams) ([]Series, error) {
+	wrap, _, err := chs.SeriesWrappedContext(ctx, characterID, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
 }
 
 // StoriesWrapped returns all stories involving the given character and match the
 // query parameters. The story slice will be encapsulated by StoryDataContainer
 // and StoryDataWrapper.
 func (chs *CharacterService) StoriesWrapped(characterID int, params *StoryParams) (*StoryDataWrapper, *http.Response, error) {
+	return chs.StoriesWrappedContext(context.Background(), characterID, params)
+}
+
+// StoriesWrappedContext is like StoriesWrapped, but the request is sent using ctx.
+func (chs *CharacterService) StoriesWrappedContext(ctx context.Context, characterID int, params *StoryParams) (*StoryDataWrapper, *http.Response, error) {
 	wrap := &StoryDataWrapper{}
-	resp, err := receiveWrapped(chs.sling, fmt.Sprintf("%d/stories", characterID), wrap, params)
+	resp, err := receiveWrapped(ctx, chs.sling, fmt.Sprintf("%d/stories", characterID), wrap, params)
 	return wrap, resp, err
 }
 
 // Stories returns all stories involving the given character and match the query parameters.
 func (chs *CharacterService) Stories(characterID int, params *StoryParams) ([]Story, error) {
-	wrap, _, err := chs.StoriesWrapped(characterID, params)
-	return wrap.Data.Results, err
+	return chs.StoriesContext(context.Background(), characterID, params)
+}
+
+// StoriesContext is like Stories, but the request is sent using ctx.
+func (chs *CharacterService) StoriesContext(ctx context.Context, characterID int, params *StoryParams) ([]Story, error) {
+	wrap, _, err := chs.StoriesWrappedContext(ctx, characterID, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
+}
+
+// AllIter returns a CharacterIterator over all characters that match the query
+// parameters, regardless of how many pages of results there are.
+func (chs *CharacterService) AllIter(params *CharacterParams) *CharacterIterator {
+	return chs.AllIterContext(context.Background(), params)
+}
+
+// AllIterContext is like AllIter, but the requests are sent using ctx.
+func (chs *CharacterService) AllIterContext(ctx context.Context, params *CharacterParams) *CharacterIterator {
+	return NewCharacterIterator(ctx, params, chs.AllWrappedContext)
+}
+
+// Walk calls fn for all characters that match the query parameters, regardless
+// of how many pages of results there are.
+func (chs *CharacterService) Walk(params *CharacterParams, fn func(Character) error) error {
+	return chs.WalkContext(context.Background(), params, fn)
+}
+
+// WalkContext is like Walk, but the requests are sent using ctx.
+func (chs *CharacterService) WalkContext(ctx context.Context, params *CharacterParams, fn func(Character) error) error {
+	return chs.AllIterContext(ctx, params).Walk(fn)
+}
+
+// ComicsIter returns a ComicIterator over all comics involving the given
+// character and match the query parameters, regardless of how many pages of
+// results there are.
+func (chs *CharacterService) ComicsIter(characterID int, params *ComicParams) *ComicIterator {
+	return chs.ComicsIterContext(context.Background(), characterID, params)
+}
+
+// ComicsIterContext is like ComicsIter, but the requests are sent using ctx.
+func (chs *CharacterService) ComicsIterContext(ctx context.Context, characterID int, params *ComicParams) *ComicIterator {
+	return NewComicIterator(ctx, params, func(ctx context.Context, params *ComicParams) (*ComicDataWrapper, *http.Response, error) {
+		return chs.ComicsWrappedContext(ctx, characterID, params)
+	})
+}
+
+// EventsIter returns an EventIterator over all events involving the given
+// character and match the query parameters, regardless of how many pages of
+// results there are.
+func (chs *CharacterService) EventsIter(characterID int, params *EventParams) *EventIterator {
+	return chs.EventsIterContext(context.Background(), characterID, params)
+}
+
+// EventsIterContext is like EventsIter, but the requests are sent using ctx.
+func (chs *CharacterService) EventsIterContext(ctx context.Context, characterID int, params *EventParams) *EventIterator {
+	return NewEventIterator(ctx, params, func(ctx context.Context, params *EventParams) (*EventDataWrapper, *http.Response, error) {
+		return chs.EventsWrappedContext(ctx, characterID, params)
+	})
+}
+
+// SeriesIter returns a SeriesIterator over all series involving the given
+// character and match the query parameters, regardless of how many pages of
+// results there are.
+func (chs *CharacterService) SeriesIter(characterID int, params *SeriesParams) *SeriesIterator {
+	return chs.SeriesIterContext(context.Background(), characterID, params)
+}
+
+// SeriesIterContext is like SeriesIter, but the requests are sent using ctx.
+func (chs *CharacterService) SeriesIterContext(ctx context.Context, characterID int, params *SeriesParams) *SeriesIterator {
+	return NewSeriesIterator(ctx, params, func(ctx context.Context, params *SeriesParams) (*SeriesDataWrapper, *http.Response, error) {
+		return chs.SeriesWrappedContext(ctx, characterID, params)
+	})
+}
+
+// StoriesIter returns a StoryIterator over all stories involving the given
+// character and match the query parameters, regardless of how many pages of
+// results there are.
+func (chs *CharacterService) StoriesIter(characterID int, params *StoryParams) *StoryIterator {
+	return chs.StoriesIterContext(context.Background(), characterID, params)
+}
+
+// StoriesIterContext is like StoriesIter, but the requests are sent using ctx.
+func (chs *CharacterService) StoriesIterContext(ctx context.Context, characterID int, params *StoryParams) *StoryIterator {
+	return NewStoryIterator(ctx, params, func(ctx context.Context, params *StoryParams) (*StoryDataWrapper, *http.Response, error) {
+		return chs.StoriesWrappedContext(ctx, characterID, params)
+	})
 }
 
 // CharacterDataWrapper provides character wrapper information returned by the API.
@@ -141,16 +316,52 @@ type Character struct {
 // CharacterParams are optional parameters to narrow the character results returned
 // by the API, as well as specify the number and order.
 type CharacterParams struct {
-	Name           string    `url:"name,omitempty"`
-	NameStartsWith string    `url:"nameStartsWith,omitempty"`
-	ModifiedSince  time.Time `url:"modifiedSince,omitempty"`
-	Comics         []int     `url:"comics,omitempty"`
-	Series         []int     `url:"series,omitempty"`
-	Events         []int     `url:"events,omitempty"`
-	Stories        []int     `url:"stories,omitempty"`
-	OrderBy        string    `url:"orderBy,omitempty"`
-	Limit          int       `url:"limit,omitempty"`
-	Offset         int       `url:"offset,omitempty"`
+	Name           string           `url:"name,omitempty"`
+	NameStartsWith string           `url:"nameStartsWith,omitempty"`
+	ModifiedSince  time.Time        `url:"modifiedSince,omitempty" layout:"2006-01-02T15:04:05-0700"`
+	Comics         IDList           `url:"comics,omitempty"`
+	Series         IDList           `url:"series,omitempty"`
+	Events         IDList           `url:"events,omitempty"`
+	Stories        IDList           `url:"stories,omitempty"`
+	OrderBy        CharacterOrderBy `url:"orderBy,omitempty"`
+	Limit          int              `url:"limit,omitempty"`
+	Offset         int              `url:"offset,omitempty"`
+}
+
+// CharacterOrderBy is the order of a list of characters. Orders may be joined with
+// commas, in decreasing priority, e.g., "name,-modified".
+type CharacterOrderBy string
+
+// The orders in which characters can be listed. The Desc variants sort in
+// descending order.
+const (
+	CharacterOrderByName         CharacterOrderBy = "name"
+	CharacterOrderByNameDesc     CharacterOrderBy = "-name"
+	CharacterOrderByModified     CharacterOrderBy = "modified"
+	CharacterOrderByModifiedDesc CharacterOrderBy = "-modified"
+)
+
+// valid reports whether the API accepts the order.
+func (o CharacterOrderBy) valid() bool {
+	return validOrder(string(o), "name", "modified")
+}
+
+// Validate returns a *ValidationError listing each of the parameters the API
+// would reject. It is called before each request is sent with the parameters.
+func (p *CharacterParams) Validate() error {
+	if p == nil {
+		return nil
+	}
+	v := newValidation("CharacterParams")
+	v.text("Name", p.Name)
+	v.text("NameStartsWith", p.NameStartsWith)
+	v.ids("Comics", p.Comics)
+	v.ids("Series", p.Series)
+	v.ids("Events", p.Events)
+	v.ids("Stories", p.Stories)
+	v.enum(p.OrderBy.valid(), "OrderBy", string(p.OrderBy))
+	v.page(p.Limit, p.Offset)
+	return v.err()
 }
 
 // CharacterList provides characters related to the parent entity.
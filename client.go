@@ -1,21 +1,32 @@
 package marvel
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/dghubble/sling"
 )
 
 const (
-	// APIURL is the base URL for all API requests.
-	APIURL = "https://gateway.marvel.com/v1/public/"
+	// APIURL is the default base URL for all API requests. See WithBaseURL and
+	// WithAPIVersion to change it.
+	APIURL = defaultGateway + "/" + defaultVersion + "/public/"
 )
 
 // Client is a Marvel client for making all API requests.
 type Client struct {
-	auth  Authenticator
-	sling *sling.Sling
+	auth       Authenticator
+	httpClient *http.Client
+	baseURL    string
+	version    string
+	sling      *sling.Sling
+	coalesce   *coalesceTransport
+	cache      *cacheTransport
+	etags      *etagTransport
+	retries    *retryTransport
+	limits     *limitTransport
 
 	Characters *CharacterService
 	Comics     *ComicService
@@ -27,17 +38,51 @@ type Client struct {
 
 // NewClient returns an API Client that will authenticate according to the provided
 // authenticator. A custom http client may also be used, otherwise pass nil for the
-// default.
-func NewClient(authenticator Authenticator, httpClient *http.Client) *Client {
+// default. The http client is not modified; its Transport is wrapped by an
+// AuthTransport so that each request is authenticated individually. Any options
+// are applied in order.
+func NewClient(authenticator Authenticator, httpClient *http.Client, opts ...Option) *Client {
+	o := &options{
+		gateway:    defaultGateway,
+		version:    defaultVersion,
+		httpClient: httpClient,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	httpClient = o.httpClient
 	if httpClient == nil {
 		httpClient = http.DefaultClient
 	}
-	base := sling.New().Client(httpClient).Base(APIURL)
-	base.QueryStruct(authenticator.Auth())
+	limits := &limitTransport{
+		base: &AuthTransport{
+			Auth: authenticator,
+			Base: httpClient.Transport,
+		},
+	}
+	retries := &retryTransport{base: limits}
+	etags := &etagTransport{capacity: DefaultETagCapacity, base: retries}
+	etags.enable(false)
+	cache := &cacheTransport{base: etags}
+	coalesce := &coalesceTransport{base: cache}
+	apiClient := *httpClient
+	apiClient.Transport = coalesce
+	base := sling.New().Client(&apiClient).Base(o.baseURL())
+	if o.userAgent != "" {
+		base.Set("User-Agent", o.userAgent)
+	}
 
 	c := &Client{
-		auth:  authenticator,
-		sling: base,
+		auth:       authenticator,
+		httpClient: httpClient,
+		baseURL:    o.baseURL(),
+		version:    o.version,
+		sling:      base,
+		coalesce:   coalesce,
+		cache:      cache,
+		etags:      etags,
+		retries:    retries,
+		limits:     limits,
 
 		Characters: NewCharacterService(base.New()),
 		Comics:     NewComicService(base.New()),
@@ -46,34 +91,115 @@ func NewClient(authenticator Authenticator, httpClient *http.Client) *Client {
 		Series:     NewSeriesService(base.New()),
 		Stories:    NewStoryService(base.New()),
 	}
+	for _, configure := range o.configure {
+		configure(c)
+	}
 
 	return c
 }
 
-// Request returns the currently prepared HTTP request.
+// Request returns the currently prepared HTTP request, including a freshly
+// generated set of authentication parameters.
 func (c *Client) Request() (*http.Request, error) {
-	return c.sling.Request()
+	req, err := c.sling.Request()
+	if err != nil {
+		return nil, err
+	}
+	addAuth(req.URL, c.auth.Auth())
+	return req, nil
 }
 
-// receiveWrapped prepares a request and unmarshals it into the provided wrapper.
-func receiveWrapped(sling *sling.Sling, pathURL string, wrapperV, paramsV interface{}) (*http.Response, error) {
-	apiErr := &APIError{}
-	resp, err := sling.New().Get(pathURL).QueryStruct(paramsV).Receive(wrapperV, apiErr)
-	if err == nil && apiErr.Code != nil {
-		err = apiErr
-	}
-	return resp, err
+// RateLimit sets the RateLimiter shared by all of the Client's services. Each
+// request waits on the limiter before it is sent. Pass nil to remove the limit.
+func (c *Client) RateLimit(limiter RateLimiter) {
+	c.limits.setLimiter(limiter)
+}
+
+// Retry sets the RetryPolicy shared by all of the Client's services. Each retry
+// is rate limited and authenticated afresh. Pass nil to disable retries.
+func (c *Client) Retry(policy *RetryPolicy) {
+	c.retries.setPolicy(policy)
+}
+
+// ConditionalRequests turns ETag revalidation on or off for all of the Client's
+// services. When on, the ETag of each response is remembered per request URL and
+// sent as If-None-Match when the URL is requested again. If the API replies 304
+// Not Modified, the remembered body is decoded in its place; use Revalidated to
+// tell such responses apart. At most DefaultETagCapacity responses, or as many
+// as set by ETagCapacity, are remembered, the least recently used being
+// forgotten first. Turning it off forgets all remembered responses.
+func (c *Client) ConditionalRequests(enabled bool) {
+	c.etags.enable(enabled)
+}
+
+// ETagCapacity sets the number of responses remembered for conditional
+// requests, forgetting all those remembered so far. A capacity less than 1 is
+// treated as 1.
+func (c *Client) ETagCapacity(capacity int) {
+	c.etags.setCapacity(capacity)
 }
 
-// APIError is the error, if any, returned by the service. Authentication error
-// responses will have Code as a string. For usage errors otherwise, Code will be
-// an integer.
-type APIError struct {
-	Code    interface{}
-	Message string
+// CoalesceRequests turns request coalescing on or off for all of the Client's
+// services. When on, which is the default, a request identical to one already
+// in flight waits for and shares its response instead of making a round trip
+// of its own; use Shared to tell such responses apart.
+func (c *Client) CoalesceRequests(enabled bool) {
+	c.coalesce.enable(enabled)
 }
 
-// Error implements the Error interface.
-func (ae *APIError) Error() string {
-	return fmt.Sprintf("marvel: %v %v", ae.Code, ae.Message)
+// Cache sets the Cache shared by all of the Client's services. Successful
+// responses are stored for ttl, unless overridden per resource by CacheTTL, and
+// served from the cache while they remain. Use Cached to tell such responses
+// apart. Pass a nil cache to disable caching.
+func (c *Client) Cache(cache Cache, ttl time.Duration) {
+	c.cache.setCache(cache, ttl)
+}
+
+// CacheTTL overrides the time responses for the given resource are cached,
+// including its sub-resource listings, e.g., comics/{id}/characters. A ttl of
+// zero disables caching for the resource.
+func (c *Client) CacheTTL(resource Resource, ttl time.Duration) {
+	c.cache.setTTL(resource, ttl)
+}
+
+// InvalidateCache removes all cached responses for the entity with the given
+// resource and ID, including its sub-resource listings.
+func (c *Client) InvalidateCache(resource Resource, id int) {
+	c.cache.invalidate(fmt.Sprintf("%s%s/%d", c.baseURL, resource, id))
+}
+
+// ImageFetcher returns an ImageFetcher which uses the Client's http client, as
+// given to NewClient, with at most workers downloads at once. Image downloads are
+// neither authenticated nor counted against the API's rate limits.
+func (c *Client) ImageFetcher(workers int) *ImageFetcher {
+	return NewImageFetcher(c.httpClient, workers)
+}
+
+// CallsToday returns the number of requests the Client has sent to the API since
+// midnight UTC, whether or not a RateLimiter is set.
+func (c *Client) CallsToday() int {
+	return c.limits.callsToday()
+}
+
+// receiveWrapped prepares a request bound to ctx and unmarshals it into the
+// provided wrapper. Parameters which can be validated are, before the request
+// is sent.
+func receiveWrapped(ctx context.Context, sling *sling.Sling, pathURL string, wrapperV, paramsV interface{}) (*http.Response, error) {
+	if v, ok := paramsV.(validator); ok {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	s := sling.New().Get(pathURL).QueryStruct(paramsV).ResponseDecoder(responseDecoder{})
+	req, err := s.Request()
+	if err != nil {
+		return nil, err
+	}
+	apiErr := &APIError{}
+	resp, err := s.Do(req.WithContext(ctx), wrapperV, apiErr)
+	if err == nil && (resp.StatusCode < 200 || resp.StatusCode > 299) {
+		apiErr.complete(resp.StatusCode, canonicalURL(req.URL))
+		err = apiErr
+	}
+	return resp, err
 }
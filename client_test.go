@@ -1,22 +1,28 @@
 package marvel_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
 	"path"
 	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/dnaeon/go-vcr/recorder"
 	"github.com/dustinrc/marvel"
+	"github.com/dustinrc/marvel/marveltest"
 	"github.com/stretchr/testify/assert"
 )
 
 // testClient acts like a normal marvel.Client, but also has a go-vcr recorder
-// associated with it.
+// associated with it, unless it is served by the offline fixture.
 type testClient struct {
 	*marvel.Client
 	rec *recorder.Recorder
@@ -24,12 +30,26 @@ type testClient struct {
 
 // newTestClient creates a new marvel.Client and associates the cassette path
 // with it. The API keys are set using the environment variables "MARVEL_PUBLIC_KEY"
-// and "MARVEL_PRIVATE_KEY".
+// and "MARVEL_PRIVATE_KEY". If they are not set, the client's requests are
+// served by a marveltest.Server loaded with the fixture testdata/api.json
+// instead, and the cassette is unused.
 func newTestClient(t *testing.T, cassette string) *testClient {
 	_, filename, _, ok := runtime.Caller(0)
 	if !ok {
 		t.Fatal("no caller information when determining file location")
 	}
+
+	pubKey, pubOK := os.LookupEnv("MARVEL_PUBLIC_KEY")
+	privKey, privOK := os.LookupEnv("MARVEL_PRIVATE_KEY")
+	if !pubOK || !privOK {
+		srv := marveltest.NewServer()
+		t.Cleanup(srv.Close)
+		if err := srv.Store.LoadFile(filepath.Join(path.Dir(filename), "testdata", "api.json")); err != nil {
+			t.Fatal("could not load offline fixture:", err)
+		}
+		return &testClient{srv.NewClient(), nil}
+	}
+
 	rec, err := recorder.New(filepath.Join(path.Dir(filename), "fixtures", cassette))
 	if err != nil {
 		t.Fatal("could not open cassette", cassette)
@@ -39,12 +59,6 @@ func newTestClient(t *testing.T, cassette string) *testClient {
 		Transport: rec,
 	}
 
-	pubKey, pubOK := os.LookupEnv("MARVEL_PUBLIC_KEY")
-	privKey, privOK := os.LookupEnv("MARVEL_PRIVATE_KEY")
-	if !pubOK || !privOK {
-		t.Fatal("environment variables MARVEL_PUBLIC_KEY and MARVEL_PRIVATE_KEY not set")
-	}
-
 	auth := marvel.NewServerSideAuth(pubKey, privKey)
 	auth.Timestamper(func() string { return "1" })
 	c := marvel.NewClient(auth, recHttpClient)
@@ -52,8 +66,39 @@ func newTestClient(t *testing.T, cassette string) *testClient {
 	return &testClient{c, rec}
 }
 
-// stopRecorder stops and closes the recorder associated with the testClient.
-func (tc *testClient) stopRecorder() { tc.rec.Stop() }
+// stopRecorder stops and closes the recorder associated with the testClient,
+// if any.
+func (tc *testClient) stopRecorder() {
+	if tc.rec != nil {
+		tc.rec.Stop()
+	}
+}
+
+// rewriteTransport sends every request to the host of url instead of the API.
+type rewriteTransport struct {
+	url *url.URL
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (rt *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = rt.url.Scheme
+	req.URL.Host = rt.url.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// newServerClient creates a new marvel.Client whose requests are answered by
+// the given handler rather than the live API. The server is closed when the
+// test completes.
+func newServerClient(t *testing.T, auth marvel.Authenticator, handler http.Handler) *marvel.Client {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal("could not parse test server URL", srv.URL)
+	}
+	return marvel.NewClient(auth, &http.Client{Transport: &rewriteTransport{u}})
+}
 
 // mockAuth exists solely for its Auth() implementation.
 type mockAuth struct{}
@@ -80,6 +125,37 @@ func TestNewClient(t *testing.T) {
 		req.URL.String())
 }
 
+func TestClientAuthPerRequest(t *testing.T) {
+	var timestamps []string
+	c := newServerClient(t, marvel.NewServerSideAuth("1234", "abcd"),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			timestamps = append(timestamps, r.URL.Query().Get("ts"))
+			fmt.Fprint(w, `{"code": 200, "data": {"results": [{"id": 1}]}}`)
+		}))
+
+	_, err := c.Characters.Get(1)
+	assert.NoError(t, err)
+	_, err = c.Characters.Get(1)
+	assert.NoError(t, err)
+
+	assert.Len(t, timestamps, 2)
+	assert.NotEmpty(t, timestamps[0])
+	assert.NotEqual(t, timestamps[0], timestamps[1], "Timestamp reused between requests")
+}
+
+func TestClientContextCancel(t *testing.T) {
+	c := newServerClient(t, &mockAuth{},
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	chars, err := c.Characters.AllContext(ctx, nil)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "Expected deadline error, got %v", err)
+	assert.Empty(t, chars)
+}
+
 func TestAPIErrorUnmarshal(t *testing.T) {
 	testCases := []struct {
 		desc, jIn, eMsg string
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/dustinrc/marvel"
+	"gopkg.in/yaml.v3"
+)
+
+// config holds the settings read from the config file and environment.
+type config struct {
+	PublicKey  string `yaml:"public_key"`
+	PrivateKey string `yaml:"private_key"`
+	BaseURL    string `yaml:"base_url"`
+
+	path string
+}
+
+// defaultConfigPath returns the config file read when none is given, or an
+// empty string if the user has no config directory.
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "marvel", "config.yaml")
+}
+
+// loadConfig reads the config file at path, or the default config file if
+// path is empty, then overrides its settings with any set in the environment.
+// The default config file need not exist.
+func loadConfig(path string, getenv func(string) string) (*config, error) {
+	cfg := &config{path: path}
+	if cfg.path == "" {
+		cfg.path = defaultConfigPath()
+	}
+	if cfg.path != "" {
+		b, err := ioutil.ReadFile(cfg.path)
+		switch {
+		case errors.Is(err, os.ErrNotExist) && path == "":
+		case err != nil:
+			return nil, err
+		default:
+			if err := yaml.Unmarshal(b, cfg); err != nil {
+				return nil, fmt.Errorf("reading %s: %v", cfg.path, err)
+			}
+		}
+	}
+
+	if v := getenv("MARVEL_PUBLIC_KEY"); v != "" {
+		cfg.PublicKey = v
+	}
+	if v := getenv("MARVEL_PRIVATE_KEY"); v != "" {
+		cfg.PrivateKey = v
+	}
+	if v := getenv("MARVEL_BASE_URL"); v != "" {
+		cfg.BaseURL = v
+	}
+	return cfg, nil
+}
+
+// client returns a Client authenticating with the config's keys.
+func (cfg *config) client() (*marvel.Client, error) {
+	if cfg.PublicKey == "" || cfg.PrivateKey == "" {
+		where := "the config file"
+		if cfg.path != "" {
+			where = cfg.path
+		}
+		return nil, fmt.Errorf("missing API keys; set MARVEL_PUBLIC_KEY and MARVEL_PRIVATE_KEY, or public_key and private_key in %s", where)
+	}
+	opts := []marvel.Option{marvel.WithUserAgent("marvel-cli")}
+	if cfg.BaseURL != "" {
+		opts = append(opts, marvel.WithBaseURL(cfg.BaseURL))
+	}
+	return marvel.NewClient(marvel.NewServerSideAuth(cfg.PublicKey, cfg.PrivateKey), nil, opts...), nil
+}
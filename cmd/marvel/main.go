@@ -0,0 +1,260 @@
+// Command marvel queries the Marvel Comic API from the command line.
+//
+// Usage:
+//
+//	marvel [flags] <resource> list [params]
+//	marvel [flags] <resource> get <id>
+//	marvel [flags] <resource> <related> <id> [params]
+//
+// The resources are characters, comics, creators, events, series and stories.
+// A related resource lists the entities of that resource which appear with the
+// given one, e.g., the stories of an event:
+//
+//	marvel characters list --name-starts-with Spider
+//	marvel comics get 21366
+//	marvel events stories 116 --limit 5
+//
+// The params of list and related commands are the fields of the listed
+// resource's Params type, named as in the API's query string but written with
+// dashes, e.g., --title-starts-with for titleStartsWith. Run a command with -h
+// to see its params.
+//
+// The API keys are read from the MARVEL_PUBLIC_KEY and MARVEL_PRIVATE_KEY
+// environment variables, falling back to the public_key and private_key of the
+// YAML config file, by default marvel/config.yaml in the user's config
+// directory.
+//
+// Results are written as a table, JSON or YAML, as chosen by --output.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustinrc/marvel"
+)
+
+func main() {
+	cmd := &command{
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+		getenv: os.Getenv,
+	}
+	os.Exit(cmd.run(context.Background(), os.Args[1:]))
+}
+
+// command runs the tool against its environment.
+type command struct {
+	stdout io.Writer
+	stderr io.Writer
+	getenv func(string) string
+}
+
+// errUsage is returned for malformed command lines, after their usage has
+// been written.
+var errUsage = errors.New("usage")
+
+// run runs the command line given by args, returning the exit status.
+func (cmd *command) run(ctx context.Context, args []string) int {
+	err := cmd.exec(ctx, args)
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		return 0
+	case errors.Is(err, errUsage):
+		return 2
+	}
+	// The package's errors already begin with its name.
+	fmt.Fprintf(cmd.stderr, "marvel: %s\n", strings.TrimPrefix(err.Error(), "marvel: "))
+	return 1
+}
+
+// exec parses args and runs the chosen subcommand.
+func (cmd *command) exec(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("marvel", flag.ContinueOnError)
+	fs.SetOutput(cmd.stderr)
+	configPath := fs.String("config", "", "read API keys from `file` (default marvel/config.yaml in the user's config directory)")
+	output := fs.String("output", formatTable, "write results as `format`: table, json or yaml")
+	baseURL := fs.String("base-url", "", "send requests to the API gateway at `url`")
+	timeout := fs.Duration("timeout", 30*time.Second, "give up on the command after `duration`")
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), usage)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return usageError(err)
+	}
+	if !validFormat(*output) {
+		fmt.Fprintf(cmd.stderr, "invalid output format %q\n", *output)
+		fs.Usage()
+		return errUsage
+	}
+	if fs.NArg() < 2 {
+		fs.Usage()
+		return errUsage
+	}
+	res, ok := resources[fs.Arg(0)]
+	if !ok {
+		fmt.Fprintf(cmd.stderr, "unknown resource %q\n", fs.Arg(0))
+		fs.Usage()
+		return errUsage
+	}
+
+	listed, run, err := cmd.parseAction(res, fs.Arg(1), fs.Args()[2:])
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(*configPath, cmd.getenv)
+	if err != nil {
+		return err
+	}
+	if *baseURL != "" {
+		cfg.BaseURL = *baseURL
+	}
+	c, err := cfg.client()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+	v, err := run(ctx, c)
+	if err != nil {
+		return err
+	}
+	return write(cmd.stdout, *output, listed, v)
+}
+
+// action is a parsed subcommand, ready to be run with a Client.
+type action func(ctx context.Context, c *marvel.Client) (interface{}, error)
+
+// parseAction parses the arguments of the named action on a resource. It
+// returns the resource whose entities the action results in, as well as the
+// action.
+func (cmd *command) parseAction(res *resource, name string, args []string) (*resource, action, error) {
+	fs := flag.NewFlagSet(res.name+" "+name, flag.ContinueOnError)
+	fs.SetOutput(cmd.stderr)
+
+	switch name {
+	case "list":
+		params := res.params()
+		addParamFlags(fs, params)
+		setUsage(fs, "marvel "+res.name+" list [params]")
+		if _, err := parseArgs(fs, args, 0); err != nil {
+			return nil, nil, err
+		}
+		return res, func(ctx context.Context, c *marvel.Client) (interface{}, error) {
+			return res.list(ctx, c, params)
+		}, nil
+	case "get":
+		setUsage(fs, "marvel "+res.name+" get <id>")
+		id, err := parseID(fs, args)
+		if err != nil {
+			return nil, nil, err
+		}
+		return res, func(ctx context.Context, c *marvel.Client) (interface{}, error) {
+			return res.get(ctx, c, id)
+		}, nil
+	}
+
+	related, ok := res.related[name]
+	if !ok {
+		fmt.Fprintf(cmd.stderr, "unknown %s action %q; want list, get or one of: %s\n",
+			res.name, name, strings.Join(res.relatedNames(), ", "))
+		return nil, nil, errUsage
+	}
+	listed := resources[name]
+	params := listed.params()
+	addParamFlags(fs, params)
+	setUsage(fs, "marvel "+res.name+" "+name+" <id> [params]")
+	id, err := parseID(fs, args)
+	if err != nil {
+		return nil, nil, err
+	}
+	return listed, func(ctx context.Context, c *marvel.Client) (interface{}, error) {
+		return related(ctx, c, id, params)
+	}, nil
+}
+
+// setUsage sets the usage of a subcommand's flag set to line followed by the
+// flags' defaults.
+func setUsage(fs *flag.FlagSet, line string) {
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: %s\n", line)
+		fs.PrintDefaults()
+	}
+}
+
+// parseArgs parses args with fs, allowing flags to follow positional
+// arguments, and returns the positional arguments. There must be exactly n.
+func parseArgs(fs *flag.FlagSet, args []string, n int) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, usageError(err)
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(positional) != n {
+		fs.Usage()
+		return nil, errUsage
+	}
+	return positional, nil
+}
+
+// parseID parses args with fs, returning their single positional argument as
+// an entity ID.
+func parseID(fs *flag.FlagSet, args []string) (int, error) {
+	positional, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return 0, err
+	}
+	id, err := strconv.Atoi(positional[0])
+	if err != nil || id <= 0 {
+		fmt.Fprintf(fs.Output(), "invalid ID %q\n", positional[0])
+		fs.Usage()
+		return 0, errUsage
+	}
+	return id, nil
+}
+
+// usageError returns the error of a failed flag.FlagSet.Parse, which has
+// already written the usage.
+func usageError(err error) error {
+	if errors.Is(err, flag.ErrHelp) {
+		return err
+	}
+	return errUsage
+}
+
+// relatedNames returns the names of the resources related to res, sorted.
+func (res *resource) relatedNames() []string {
+	names := make([]string, 0, len(res.related))
+	for name := range res.related {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+const usage = `usage: marvel [flags] <resource> list [params]
+       marvel [flags] <resource> get <id>
+       marvel [flags] <resource> <related> <id> [params]
+
+resources: characters, comics, creators, events, series, stories
+
+flags:
+`
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dustinrc/marvel"
+	"github.com/dustinrc/marvel/marveltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(t *testing.T) *marveltest.Server {
+	srv := marveltest.NewServer()
+	t.Cleanup(srv.Close)
+	modified := marvel.Time{Time: time.Date(2014, 4, 29, 14, 18, 17, 0, time.UTC)}
+	srv.Store.AddCharacters(
+		marvel.Character{ID: 1009610, Name: "Spider-Man", Modified: modified},
+		marvel.Character{ID: 1009609, Name: "Spider-Girl (May Parker)"},
+		marvel.Character{ID: 1009368, Name: "Iron Man"},
+	)
+	srv.Store.AddComics(marvel.Comic{ID: 21366, Title: "Avengers: The Initiative (2007) #14", IssueNumber: 14, Format: "Comic"})
+	srv.Store.AddEvents(marvel.Event{ID: 116, Title: "Acts of Vengeance!"})
+	event := marvel.EventList{Items: []marvel.EventSummary{{Summary: marvel.Summary{
+		ResourceURI: "http://gateway.marvel.com/v1/public/events/116",
+	}}}}
+	srv.Store.AddStories(
+		marvel.Story{ID: 12964, Title: "Cover #12964", Type: "cover", Events: event},
+		marvel.Story{ID: 12965, Title: "Interior #12965", Type: "interiorStory", Events: event},
+	)
+	return srv
+}
+
+func TestRun(t *testing.T) {
+	srv := newTestServer(t)
+	env := map[string]string{
+		"MARVEL_PUBLIC_KEY":  marveltest.PublicKey,
+		"MARVEL_PRIVATE_KEY": marveltest.PrivateKey,
+	}
+	testCases := []struct {
+		desc   string
+		args   []string
+		status int
+		stdout string
+		stderr string
+	}{
+		{
+			desc:   "list",
+			args:   []string{"characters", "list", "--name-starts-with", "Spider", "--order-by", "-name"},
+			stdout: "ID       NAME                      MODIFIED\n1009610  Spider-Man                2014-04-29\n1009609  Spider-Girl (May Parker)  \n",
+		},
+		{
+			desc:   "get",
+			args:   []string{"comics", "get", "21366"},
+			stdout: "ID     TITLE                                ISSUE  FORMAT  MODIFIED\n21366  Avengers: The Initiative (2007) #14  14     Comic   \n",
+		},
+		{
+			desc:   "related with params after the ID",
+			args:   []string{"events", "stories", "116", "--limit", "1"},
+			stdout: "ID     TITLE         TYPE\n12964  Cover #12964  cover\n",
+		},
+		{
+			desc:   "JSON",
+			args:   []string{"-output", "json", "characters", "get", "1009368"},
+			stdout: "{\n  \"id\": 1009368,\n  \"name\": \"Iron Man\",\n  \"modified\": \"0001-01-01T00:00:00+0000\",\n  \"resourceURI\": \"http://gateway.marvel.com/v1/public/characters/1009368\",\n  \"comics\": {},\n  \"stories\": {},\n  \"events\": {},\n  \"series\": {}\n}\n",
+		},
+		{
+			desc:   "YAML",
+			args:   []string{"-output", "yaml", "events", "stories", "116", "--order-by", "-id"},
+			stdout: "- id: 12965\n  title: 'Interior #12965'\n  resourceUri: http://gateway.marvel.com/v1/public/stories/12965\n  type: interiorStory\n  modified: 0001-01-01T00:00:00+0000\n  comics: {}\n  series: {}\n  events:\n    items:\n      - resourceURI: http://gateway.marvel.com/v1/public/events/116\n  characters: {}\n  creators: {}\n- id: 12964\n  title: 'Cover #12964'\n  resourceUri: http://gateway.marvel.com/v1/public/stories/12964\n  type: cover\n  modified: 0001-01-01T00:00:00+0000\n  comics: {}\n  series: {}\n  events:\n    items:\n      - resourceURI: http://gateway.marvel.com/v1/public/events/116\n  characters: {}\n  creators: {}\n",
+		},
+		{
+			desc:   "unknown resource",
+			args:   []string{"heroes", "list"},
+			status: 2,
+		},
+		{
+			desc:   "unknown related resource",
+			args:   []string{"characters", "creators", "1009610"},
+			status: 2,
+		},
+		{
+			desc:   "missing ID",
+			args:   []string{"comics", "get"},
+			status: 2,
+		},
+		{
+			desc:   "invalid params",
+			args:   []string{"characters", "list", "--limit", "101"},
+			status: 1,
+			stderr: "marvel: invalid CharacterParams: Limit 101 is over 100\n",
+		},
+		{
+			desc:   "API error",
+			args:   []string{"comics", "get", "1"},
+			status: 1,
+			stderr: "marvel: comics/1 not found\n",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			cmd := &command{
+				stdout: stdout,
+				stderr: stderr,
+				getenv: func(key string) string { return env[key] },
+			}
+			args := append([]string{"-config", writeConfig(t, "base_url: "+srv.URL)}, tC.args...)
+			status := cmd.run(context.Background(), args)
+			assert.Equal(t, tC.status, status, stderr.String())
+			if tC.status == 0 {
+				assert.Equal(t, tC.stdout, stdout.String())
+			}
+			if tC.stderr != "" {
+				assert.Equal(t, tC.stderr, stderr.String())
+			}
+		})
+	}
+}
+
+func writeConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := writeConfig(t, "public_key: file-public\nprivate_key: file-private\n")
+	testCases := []struct {
+		desc     string
+		path     string
+		env      map[string]string
+		expected config
+		err      bool
+	}{
+		{
+			desc:     "file",
+			path:     path,
+			expected: config{PublicKey: "file-public", PrivateKey: "file-private", path: path},
+		},
+		{
+			desc:     "environment overrides file",
+			path:     path,
+			env:      map[string]string{"MARVEL_PRIVATE_KEY": "env-private", "MARVEL_BASE_URL": "http://localhost"},
+			expected: config{PublicKey: "file-public", PrivateKey: "env-private", BaseURL: "http://localhost", path: path},
+		},
+		{
+			desc: "missing file",
+			path: filepath.Join(t.TempDir(), "missing.yaml"),
+			err:  true,
+		},
+		{
+			desc: "invalid file",
+			path: writeConfig(t, "public_key: [\n"),
+			err:  true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			cfg, err := loadConfig(tC.path, func(key string) string { return tC.env[key] })
+			if tC.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tC.expected, *cfg)
+		})
+	}
+}
+
+func TestParamFlags(t *testing.T) {
+	t.Run("names", func(t *testing.T) {
+		assert.Equal(t, "title-starts-with", flagName("titleStartsWith"))
+		assert.Equal(t, "digital-id", flagName("digitalId"))
+		assert.Equal(t, "limit", flagName("limit"))
+	})
+	t.Run("values", func(t *testing.T) {
+		params := &marvel.ComicParams{}
+		fs := flag.NewFlagSet("comics list", flag.ContinueOnError)
+		fs.SetOutput(ioutil.Discard)
+		addParamFlags(fs, params)
+		err := fs.Parse([]string{
+			"--format", "trade paperback",
+			"--no-variants",
+			"--date-range", "2013-01-01,2013-01-02",
+			"--start-year", "2013",
+			"--modified-since", "2014-01-01",
+			"--characters", "1009610, 1009368",
+			"--order-by", "-onsaleDate",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, &marvel.ComicParams{
+			Format:     marvel.FormatTradePaperback,
+			NoVariants: true,
+			DateRange: marvel.DateRange{
+				Start: time.Date(2013, 1, 1, 0, 0, 0, 0, time.UTC),
+				End:   time.Date(2013, 1, 2, 0, 0, 0, 0, time.UTC),
+			},
+			StartYear:     2013,
+			ModifiedSince: time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC),
+			Characters:    marvel.IDList{1009610, 1009368},
+			OrderBy:       marvel.ComicOrderByOnSaleDateDesc,
+		}, params)
+
+		assert.Error(t, fs.Parse([]string{"--start-year", "soon"}))
+		assert.Error(t, fs.Parse([]string{"--date-range", "2013-01-01"}))
+		assert.Error(t, fs.Parse([]string{"--characters", "1009610,spider-man"}))
+
+		series := &marvel.SeriesParams{}
+		fs = flag.NewFlagSet("series list", flag.ContinueOnError)
+		addParamFlags(fs, series)
+		assert.NoError(t, fs.Parse([]string{"--contains", "comic, digest"}))
+		assert.Equal(t, marvel.FormatList{marvel.FormatComic, marvel.FormatDigest}, series.Contains)
+	})
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The formats in which results can be written.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+)
+
+func validFormat(format string) bool {
+	return format == formatTable || format == formatJSON || format == formatYAML
+}
+
+// write writes v, an entity or slice of entities of res, to w in format.
+func write(w io.Writer, format string, res *resource, v interface{}) error {
+	switch format {
+	case formatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case formatYAML:
+		return writeYAML(w, v)
+	}
+	return writeTable(w, res, v)
+}
+
+// writeTable writes v as a table of res's columns, one row per entity.
+func writeTable(w io.Writer, res *resource, v interface{}) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(res.header, "\t"))
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Slice {
+		fmt.Fprintln(tw, strings.Join(res.row(rv.Interface()), "\t"))
+		return tw.Flush()
+	}
+	for i := 0; i < rv.Len(); i++ {
+		fmt.Fprintln(tw, strings.Join(res.row(rv.Index(i).Interface()), "\t"))
+	}
+	return tw.Flush()
+}
+
+// writeYAML writes v as YAML, with the same keys, in the same order, as its
+// JSON encoding.
+func writeYAML(w io.Writer, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return err
+	}
+	blockStyle(&doc)
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// blockStyle clears the styles of n and its descendants, which are flow
+// styles when decoded from JSON, so they are encoded in YAML's block style.
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, child := range n.Content {
+		blockStyle(child)
+	}
+}
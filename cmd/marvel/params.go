@@ -0,0 +1,170 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/dustinrc/marvel"
+)
+
+// dateLayout is the layout of dates given as flags.
+const dateLayout = "2006-01-02"
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	dateRangeType = reflect.TypeOf(marvel.DateRange{})
+	idListType    = reflect.TypeOf(marvel.IDList{})
+	formatsType   = reflect.TypeOf(marvel.FormatList{})
+)
+
+// addParamFlags defines a flag on fs for each field of params, which must
+// point to one of the Params structs. Each flag is named after the field's
+// query parameter, e.g., --name-starts-with for nameStartsWith, and sets the
+// field directly.
+func addParamFlags(fs *flag.FlagSet, params interface{}) {
+	v := reflect.ValueOf(params).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		key := strings.Split(field.Tag.Get("url"), ",")[0]
+		if key == "" || key == "-" {
+			continue
+		}
+		fs.Var(paramFlag{v.Field(i)}, flagName(key), paramUsage(key, field.Type))
+	}
+}
+
+// flagName returns the flag name of a query parameter, with its words
+// lowercased and separated by dashes.
+func flagName(key string) string {
+	var b strings.Builder
+	for _, r := range key {
+		if unicode.IsUpper(r) {
+			b.WriteByte('-')
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// paramUsage returns the usage of the flag for a query parameter, naming the
+// form of its value.
+func paramUsage(key string, typ reflect.Type) string {
+	switch typ {
+	case timeType:
+		return fmt.Sprintf("the %s parameter, a `date` (%s) or RFC 3339 time", key, dateLayout)
+	case dateRangeType:
+		return fmt.Sprintf("the %s parameter, `start,end` dates (%s)", key, dateLayout)
+	case idListType:
+		return fmt.Sprintf("the %s parameter, comma-separated `IDs`", key)
+	case formatsType:
+		return fmt.Sprintf("the %s parameter, comma-separated `formats`", key)
+	}
+	switch typ.Kind() {
+	case reflect.Int:
+		return fmt.Sprintf("the %s parameter, a `number`", key)
+	case reflect.String:
+		return fmt.Sprintf("the %s parameter, a `string`", key)
+	}
+	return fmt.Sprintf("the %s parameter", key)
+}
+
+// paramFlag is a flag.Value which sets a field of a Params struct.
+type paramFlag struct {
+	field reflect.Value
+}
+
+// String implements the flag.Value interface.
+func (f paramFlag) String() string {
+	if !f.field.IsValid() || f.field.IsZero() {
+		return ""
+	}
+	if t, ok := f.field.Interface().(time.Time); ok {
+		return t.Format(time.RFC3339)
+	}
+	return fmt.Sprint(f.field.Interface())
+}
+
+// IsBoolFlag lets boolean params be given without a value.
+func (f paramFlag) IsBoolFlag() bool {
+	return f.field.IsValid() && f.field.Kind() == reflect.Bool
+}
+
+// Set implements the flag.Value interface.
+func (f paramFlag) Set(s string) error {
+	switch f.field.Type() {
+	case timeType:
+		t, err := parseTime(s)
+		if err != nil {
+			return err
+		}
+		f.field.Set(reflect.ValueOf(t))
+		return nil
+	case dateRangeType:
+		dates := strings.Split(s, ",")
+		if len(dates) != 2 {
+			return fmt.Errorf("want start,end dates")
+		}
+		var dr marvel.DateRange
+		var err error
+		if dr.Start, err = time.Parse(dateLayout, strings.TrimSpace(dates[0])); err != nil {
+			return err
+		}
+		if dr.End, err = time.Parse(dateLayout, strings.TrimSpace(dates[1])); err != nil {
+			return err
+		}
+		f.field.Set(reflect.ValueOf(dr))
+		return nil
+	case idListType:
+		var ids marvel.IDList
+		for _, part := range strings.Split(s, ",") {
+			id, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return fmt.Errorf("invalid ID %q", part)
+			}
+			ids = append(ids, id)
+		}
+		f.field.Set(reflect.ValueOf(ids))
+		return nil
+	case formatsType:
+		var formats marvel.FormatList
+		for _, part := range strings.Split(s, ",") {
+			formats = append(formats, marvel.Format(strings.TrimSpace(part)))
+		}
+		f.field.Set(reflect.ValueOf(formats))
+		return nil
+	}
+
+	switch f.field.Kind() {
+	case reflect.String:
+		f.field.SetString(s)
+	case reflect.Int:
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", s)
+		}
+		f.field.SetInt(int64(n))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		f.field.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported parameter type %s", f.field.Type())
+	}
+	return nil
+}
+
+// parseTime parses a date, or a time in RFC 3339 format.
+func parseTime(s string) (time.Time, error) {
+	if t, err := time.Parse(dateLayout, s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
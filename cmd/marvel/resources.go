@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/dustinrc/marvel"
+)
+
+// resource describes how to query a resource and show its entities.
+type resource struct {
+	name string
+	// params returns a pointer to new, empty Params for listing the resource.
+	params func() interface{}
+	list   func(ctx context.Context, c *marvel.Client, params interface{}) (interface{}, error)
+	get    func(ctx context.Context, c *marvel.Client, id int) (interface{}, error)
+	// related lists the entities of each related resource which appear with
+	// an entity, given the related resource's Params.
+	related map[string]func(ctx context.Context, c *marvel.Client, id int, params interface{}) (interface{}, error)
+	// header and row give the table columns of an entity.
+	header []string
+	row    func(v interface{}) []string
+}
+
+// resources are the resources which can be queried, by name.
+var resources = map[string]*resource{
+	"characters": {
+		name:   "characters",
+		params: func() interface{} { return &marvel.CharacterParams{} },
+		list: func(ctx context.Context, c *marvel.Client, params interface{}) (interface{}, error) {
+			return c.Characters.AllContext(ctx, params.(*marvel.CharacterParams))
+		},
+		get: func(ctx context.Context, c *marvel.Client, id int) (interface{}, error) {
+			return c.Characters.GetContext(ctx, id)
+		},
+		related: map[string]func(context.Context, *marvel.Client, int, interface{}) (interface{}, error){
+			"comics": func(ctx context.Context, c *marvel.Client, id int, params interface{}) (interface{}, error) {
+				return c.Characters.ComicsContext(ctx, id, params.(*marvel.ComicParams))
+			},
+			"events": func(ctx context.Context, c *marvel.Client, id int, params interface{}) (interface{}, error) {
+				return c.Characters.EventsContext(ctx, id, params.(*marvel.EventParams))
+			},
+			"series": func(ctx context.Context, c *marvel.Client, id int, params interface{}) (interface{}, error) {
+				return c.Characters.SeriesContext(ctx, id, params.(*marvel.SeriesParams))
+			},
+			"stories": func(ctx context.Context, c *marvel.Client, id int, params interface{}) (interface{}, error) {
+				return c.Characters.StoriesContext(ctx, id, params.(*marvel.StoryParams))
+			},
+		},
+		header: []string{"ID", "NAME", "MODIFIED"},
+		row: func(v interface{}) []string {
+			ch := v.(marvel.Character)
+			return []string{strconv.Itoa(ch.ID), ch.Name, date(ch.Modified)}
+		},
+	},
+	"comics": {
+		name:   "comics",
+		params: func() interface{} { return &marvel.ComicParams{} },
+		list: func(ctx context.Context, c *marvel.Client, params interface{}) (interface{}, error) {
+			return c.Comics.AllContext(ctx, params.(*marvel.ComicParams))
+		},
+		get: func(ctx context.Context, c *marvel.Client, id int) (interface{}, error) {
+			return c.Comics.GetContext(ctx, id)
+		},
+		related: map[string]func(context.Context, *marvel.Client, int, interface{}) (interface{}, error){
+			"characters": func(ctx context.Context, c *marvel.Client, id int, params interface{}) (interface{}, error) {
+				return c.Comics.CharactersContext(ctx, id, params.(*marvel.CharacterParams))
+			},
+			"creators": func(ctx context.Context, c *marvel.Client, id int, params interface{}) (interface{}, error) {
+				return c.Comics.CreatorsContext(ctx, id, params.(*marvel.CreatorParams))
+			},
+			"events": func(ctx context.Context, c *marvel.Client, id int, params interface{}) (interface{}, error) {
+				return c.Comics.EventsContext(ctx, id, params.(*marvel.EventParams))
+			},
+			"stories": func(ctx context.Context, c *marvel.Client, id int, params interface{}) (interface{}, error) {
+				return c.Comics.StoriesContext(ctx, id, params.(*marvel.StoryParams))
+			},
+		},
+		header: []string{"ID", "TITLE", "ISSUE", "FORMAT", "MODIFIED"},
+		row: func(v interface{}) []string {
+			co := v.(marvel.Comic)
+			return []string{strconv.Itoa(co.ID), co.Title, strconv.Itoa(co.IssueNumber), co.Format, date(co.Modified)}
+		},
+	},
+	"creators": {
+		name:   "creators",
+		params: func() interface{} { return &marvel.CreatorParams{} },
+		list: func(ctx context.Context, c *marvel.Client, params interface{}) (interface{}, error) {
+			return c.Creators.AllContext(ctx, params.(*marvel.CreatorParams))
+		},
+		get: func(ctx context.Context, c *marvel.Client, id int) (interface{}, error) {
+			return c.Creators.GetContext(ctx, id)
+		},
+		related: map[string]func(context.Context, *marvel.Client, int, interface{}) (interface{}, error){
+			"comics": func(ctx context.Context, c *marvel.Client, id int, params interface{}) (interface{}, error) {
+				return c.Creators.ComicsContext(ctx, id, params.(*marvel.ComicParams))
+			},
+			"events": func(ctx context.Context, c *marvel.Client, id int, params interface{}) (interface{}, error) {
+				return c.Creators.EventsContext(ctx, id, params.(*marvel.EventParams))
+			},
+			"series": func(ctx context.Context, c *marvel.Client, id int, params interface{}) (interface{}, error) {
+				return c.Creators.SeriesContext(ctx, id, params.(*marvel.SeriesParams))
+			},
+			"stories": func(ctx context.Context, c *marvel.Client, id int, params interface{}) (interface{}, error) {
+				return c.Creators.StoriesContext(ctx, id, params.(*marvel.StoryParams))
+			},
+		},
+		header: []string{"ID", "NAME", "MODIFIED"},
+		row: func(v interface{}) []string {
+			ctr := v.(marvel.Creator)
+			return []string{strconv.Itoa(ctr.ID), ctr.FullName, date(ctr.Modified)}
+		},
+	},
+	"events": {
+		name:   "events",
+		params: func() interface{} { return &marvel.EventParams{} },
+		list: func(ctx context.Context, c *marvel.Client, params interface{}) (interface{}, error) {
+			return c.Events.AllContext(ctx, params.(*marvel.EventParams))
+		},
+		get: func(ctx context.Context, c *marvel.Client, id int) (interface{}, error) {
+			return c.Events.GetContext(ctx, id)
+		},
+		related: map[string]func(context.Context, *marvel.Client, int, interface{}) (interface{}, error){
+			"characters": func(ctx context.Context, c *marvel.Client, id int, params interface{}) (interface{}, error) {
+				return c.Events.CharactersContext(ctx, id, params.(*marvel.CharacterParams))
+			},
+			"comics": func(ctx context.Context, c *marvel.Client, id int, params interface{}) (interface{}, error) {
+				return c.Events.ComicsContext(ctx, id, params.(*marvel.ComicParams))
+			},
+			"creators": func(ctx context.Context, c *marvel.Client, id int, params interface{}) (interface{}, error) {
+				return c.Events.CreatorsContext(ctx, id, params.(*marvel.CreatorParams))
+			},
+			"series": func(ctx context.Context, c *marvel.Client, id int, params interface{}) (interface{}, error) {
+				return c.Events.SeriesContext(ctx, id, params.(*marvel.SeriesParams))
+			},
+			"stories": func(ctx context.Context, c *marvel.Client, id int, params interface{}) (interface{}, error) {
+				return c.Events.StoriesContext(ctx, id, params.(*marvel.StoryParams))
+			},
+		},
+		header: []string{"ID", "TITLE", "START", "END"},
+		row: func(v interface{}) []string {
+			ev := v.(marvel.Event)
+			return []string{strconv.Itoa(ev.ID), ev.Title, date(ev.Start), date(ev.End)}
+		},
+	},
+	"series": {
+		name:   "series",
+		params: func() interface{} { return &marvel.SeriesParams{} },
+		list: func(ctx context.Context, c *marvel.Client, params interface{}) (interface{}, error) {
+			return c.Series.AllContext(ctx, params.(*marvel.SeriesParams))
+		},
+		get: func(ctx context.Context, c *marvel.Client, id int) (interface{}, error) {
+			return c.Series.GetContext(ctx, id)
+		},
+		related: map[string]func(context.Context, *marvel.Client, int, interface{}) (interface{}, error){
+			"characters": func(ctx context.Context, c *marvel.Client, id int, params interface{}) (interface{}, error) {
+				return c.Series.CharactersContext(ctx, id, params.(*marvel.CharacterParams))
+			},
+			"comics": func(ctx context.Context, c *marvel.Client, id int, params interface{}) (interface{}, error) {
+				return c.Series.ComicsContext(ctx, id, params.(*marvel.ComicParams))
+			},
+			"creators": func(ctx context.Context, c *marvel.Client, id int, params interface{}) (interface{}, error) {
+				return c.Series.CreatorsContext(ctx, id, params.(*marvel.CreatorParams))
+			},
+			"events": func(ctx context.Context, c *marvel.Client, id int, params interface{}) (interface{}, error) {
+				return c.Series.EventsContext(ctx, id, params.(*marvel.EventParams))
+			},
+			"stories": func(ctx context.Context, c *marvel.Client, id int, params interface{}) (interface{}, error) {
+				return c.Series.StoriesContext(ctx, id, params.(*marvel.StoryParams))
+			},
+		},
+		header: []string{"ID", "TITLE", "YEARS", "TYPE"},
+		row: func(v interface{}) []string {
+			sr := v.(marvel.Series)
+			return []string{strconv.Itoa(sr.ID), sr.Title, years(sr.StartYear, sr.EndYear), sr.Type}
+		},
+	},
+	"stories": {
+		name:   "stories",
+		params: func() interface{} { return &marvel.StoryParams{} },
+		list: func(ctx context.Context, c *marvel.Client, params interface{}) (interface{}, error) {
+			return c.Stories.AllContext(ctx, params.(*marvel.StoryParams))
+		},
+		get: func(ctx context.Context, c *marvel.Client, id int) (interface{}, error) {
+			return c.Stories.GetContext(ctx, id)
+		},
+		related: map[string]func(context.Context, *marvel.Client, int, interface{}) (interface{}, error){
+			"characters": func(ctx context.Context, c *marvel.Client, id int, params interface{}) (interface{}, error) {
+				return c.Stories.CharactersContext(ctx, id, params.(*marvel.CharacterParams))
+			},
+			"comics": func(ctx context.Context, c *marvel.Client, id int, params interface{}) (interface{}, error) {
+				return c.Stories.ComicsContext(ctx, id, params.(*marvel.ComicParams))
+			},
+			"creators": func(ctx context.Context, c *marvel.Client, id int, params interface{}) (interface{}, error) {
+				return c.Stories.CreatorsContext(ctx, id, params.(*marvel.CreatorParams))
+			},
+			"events": func(ctx context.Context, c *marvel.Client, id int, params interface{}) (interface{}, error) {
+				return c.Stories.EventsContext(ctx, id, params.(*marvel.EventParams))
+			},
+			"series": func(ctx context.Context, c *marvel.Client, id int, params interface{}) (interface{}, error) {
+				return c.Stories.SeriesContext(ctx, id, params.(*marvel.SeriesParams))
+			},
+		},
+		header: []string{"ID", "TITLE", "TYPE"},
+		row: func(v interface{}) []string {
+			st := v.(marvel.Story)
+			return []string{strconv.Itoa(st.ID), st.Title, st.Type}
+		},
+	},
+}
+
+// date formats the date of t, or nothing if t is zero.
+func date(t marvel.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(dateLayout)
+}
+
+// years formats the years a series ran.
+func years(start, end int) string {
+	if start == 0 {
+		return ""
+	}
+	if end == 0 || end == start {
+		return strconv.Itoa(start)
+	}
+	return fmt.Sprintf("%d-%d", start, end)
+}
@@ -0,0 +1,122 @@
+package marvel
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// sharedHeader marks responses which were shared with another, identical
+// request that was already in flight.
+const sharedHeader = "X-Marvel-Shared"
+
+// Shared reports whether the response was received by an identical request
+// already in flight, rather than by a round trip of its own.
+func Shared(resp *http.Response) bool {
+	return resp != nil && resp.Header.Get(sharedHeader) != ""
+}
+
+// flight is a request in flight, whose outcome is shared with identical
+// requests made before it completes.
+type flight struct {
+	done     chan struct{}
+	status   string
+	code     int
+	header   http.Header
+	body     []byte
+	err      error
+	canceled bool
+}
+
+// response returns a new response holding the flight's status, header and
+// body, marked as shared if requested.
+func (f *flight) response(req *http.Request, shared bool) *http.Response {
+	header := f.header.Clone()
+	if shared {
+		header.Set(sharedHeader, "true")
+	}
+	header.Set("Content-Length", strconv.Itoa(len(f.body)))
+	return &http.Response{
+		Status:        f.status,
+		StatusCode:    f.code,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(f.body)),
+		ContentLength: int64(len(f.body)),
+		Request:       req,
+	}
+}
+
+// coalesceTransport is an http.RoundTripper which lets concurrent, identical
+// GET requests share a single round trip. Requests are identical if their
+// canonical URLs, without authentication parameters, are equal.
+type coalesceTransport struct {
+	mu       sync.Mutex
+	disabled bool
+	flights  map[string]*flight
+	base     http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (ct *coalesceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return ct.base.RoundTrip(req)
+	}
+	key := canonicalURL(req.URL)
+	ct.mu.Lock()
+	if ct.disabled {
+		ct.mu.Unlock()
+		return ct.base.RoundTrip(req)
+	}
+	if f, ok := ct.flights[key]; ok {
+		ct.mu.Unlock()
+		select {
+		case <-f.done:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		switch {
+		case f.canceled:
+			// The request sharing its round trip gave up, not the API.
+			return ct.RoundTrip(req)
+		case f.err != nil:
+			return nil, f.err
+		}
+		return f.response(req, true), nil
+	}
+	if ct.flights == nil {
+		ct.flights = make(map[string]*flight)
+	}
+	f := &flight{done: make(chan struct{})}
+	ct.flights[key] = f
+	ct.mu.Unlock()
+
+	defer func() {
+		ct.mu.Lock()
+		delete(ct.flights, key)
+		ct.mu.Unlock()
+		close(f.done)
+	}()
+	resp, err := ct.base.RoundTrip(req)
+	if err == nil {
+		f.status, f.code, f.header = resp.Status, resp.StatusCode, resp.Header
+		f.body, err = ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+	}
+	if err != nil {
+		f.err, f.canceled = err, req.Context().Err() != nil
+		return nil, err
+	}
+	return f.response(req, false), nil
+}
+
+// enable turns request coalescing on or off.
+func (ct *coalesceTransport) enable(enabled bool) {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	ct.disabled = !enabled
+}
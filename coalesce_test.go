@@ -0,0 +1,110 @@
+package marvel_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dustinrc/marvel"
+	"github.com/stretchr/testify/assert"
+)
+
+// blockingHandler serves a single character, holding every request until
+// release is closed.
+type blockingHandler struct {
+	requests int32
+	release  chan struct{}
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (bh *blockingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	atomic.AddInt32(&bh.requests, 1)
+	<-bh.release
+	fmt.Fprint(w, `{"code": 200, "data": {"results": [{"id": 1009610}]}}`)
+}
+
+// getConcurrently calls GetWrapped n times at once, returning how many of the
+// responses were shared.
+func getConcurrently(t *testing.T, c *marvel.Client, bh *blockingHandler, n int) int {
+	var wg sync.WaitGroup
+	var shared int32
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wrap, resp, err := c.Characters.GetWrapped(1009610)
+			assert.NoError(t, err)
+			assert.Equal(t, 1009610, wrap.Data.Results[0].ID)
+			if marvel.Shared(resp) {
+				atomic.AddInt32(&shared, 1)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(bh.release)
+	wg.Wait()
+	return int(shared)
+}
+
+func TestClientCoalesceRequests(t *testing.T) {
+	bh := &blockingHandler{release: make(chan struct{})}
+	c := newServerClient(t, &mockAuth{}, bh)
+
+	shared := getConcurrently(t, c, bh, 10)
+	assert.EqualValues(t, 1, bh.requests)
+	assert.Equal(t, 9, shared)
+
+	t.Run("different requests are not coalesced", func(t *testing.T) {
+		bh := &blockingHandler{release: make(chan struct{})}
+		c := newServerClient(t, &mockAuth{}, bh)
+		var wg sync.WaitGroup
+		for _, id := range []int{1, 2, 3} {
+			wg.Add(1)
+			go func(id int) {
+				defer wg.Done()
+				_, err := c.Characters.Get(id)
+				assert.NoError(t, err)
+			}(id)
+		}
+		time.Sleep(50 * time.Millisecond)
+		close(bh.release)
+		wg.Wait()
+		assert.EqualValues(t, 3, bh.requests)
+	})
+	t.Run("canceled requests do not fail others", func(t *testing.T) {
+		bh := &blockingHandler{release: make(chan struct{})}
+		c := newServerClient(t, &mockAuth{}, bh)
+		ctx, cancel := context.WithCancel(context.Background())
+		first := make(chan error)
+		go func() {
+			_, err := c.Characters.GetContext(ctx, 1009610)
+			first <- err
+		}()
+		time.Sleep(20 * time.Millisecond)
+		second := make(chan error)
+		go func() {
+			_, err := c.Characters.Get(1009610)
+			second <- err
+		}()
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+		assert.Error(t, <-first)
+		close(bh.release)
+		assert.NoError(t, <-second)
+		assert.EqualValues(t, 2, bh.requests)
+	})
+}
+
+func TestClientCoalesceRequestsOff(t *testing.T) {
+	bh := &blockingHandler{release: make(chan struct{})}
+	c := newServerClient(t, &mockAuth{}, bh)
+	c.CoalesceRequests(false)
+
+	shared := getConcurrently(t, c, bh, 5)
+	assert.EqualValues(t, 5, bh.requests)
+	assert.Zero(t, shared)
+}
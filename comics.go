@@ -1,8 +1,11 @@
 package marvel
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/dghubble/sling"
@@ -23,92 +26,266 @@ func NewComicService(sling *sling.Sling) *ComicService {
 // AllWrapped returns all comics that match the query parameters. The comic
 // slice will be encapsulated by ComicDataContainer and ComicDataWrapper.
 func (cos *ComicService) AllWrapped(params *ComicParams) (*ComicDataWrapper, *http.Response, error) {
+	return cos.AllWrappedContext(context.Background(), params)
+}
+
+// AllWrappedContext is like AllWrapped, but the request is sent using ctx.
+func (cos *ComicService) AllWrappedContext(ctx context.Context, params *ComicParams) (*ComicDataWrapper, *http.Response, error) {
 	wrap := &ComicDataWrapper{}
-	resp, err := receiveWrapped(cos.sling, "../comics", wrap, params)
+	resp, err := receiveWrapped(ctx, cos.sling, "../comics", wrap, params)
 	return wrap, resp, err
 }
 
 // All returns all comics that match the query parameters.
 func (cos *ComicService) All(params *ComicParams) ([]Comic, error) {
-	wrap, _, err := cos.AllWrapped(params)
-	return wrap.Data.Results, err
+	return cos.AllContext(context.Background(), params)
+}
+
+// AllContext is like All, but the request is sent using ctx.
+func (cos *ComicService) AllContext(ctx context.Context, params *ComicParams) ([]Comic, error) {
+	wrap, _, err := cos.AllWrappedContext(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
 }
 
 // GetWrapped returns the comic associated with the given ID. The comic
 // details will be encapsulated by ComicDataContainer and ComicDataWrapper.
 func (cos *ComicService) GetWrapped(comicID int) (*ComicDataWrapper, *http.Response, error) {
+	return cos.GetWrappedContext(context.Background(), comicID)
+}
+
+// GetWrappedContext is like GetWrapped, but the request is sent using ctx.
+func (cos *ComicService) GetWrappedContext(ctx context.Context, comicID int) (*ComicDataWrapper, *http.Response, error) {
 	wrap := &ComicDataWrapper{}
-	resp, err := receiveWrapped(cos.sling, fmt.Sprintf("%d", comicID), wrap, nil)
+	resp, err := receiveWrapped(ctx, cos.sling, fmt.Sprintf("%d", comicID), wrap, nil)
 	return wrap, resp, err
 }
 
-// Get returns the comic associated with the given ID.
+// Get returns the comic associated with the given ID. A NotFoundError is
+// returned if there is no such comic.
 func (cos *ComicService) Get(comicID int) (*Comic, error) {
-	wrap, _, err := cos.GetWrapped(comicID)
-	if err != nil {
+	return cos.GetContext(context.Background(), comicID)
+}
+
+// GetContext is like Get, but the request is sent using ctx.
+func (cos *ComicService) GetContext(ctx context.Context, comicID int) (*Comic, error) {
+	wrap, _, err := cos.GetWrappedContext(ctx, comicID)
+	if err = getError(ComicsResource, comicID, err, len(wrap.Data.Results)); err != nil {
 		return nil, err
 	}
 	return &wrap.Data.Results[0], nil
 }
 
+// GetMany returns the comics associated with the given IDs, keyed by ID. The
+// comics are requested concurrently, at most workers at a time, and any errors
+// are returned keyed by the ID that caused them. A workers value less than 1 is
+// treated as 1.
+func (cos *ComicService) GetMany(comicIDs []int, workers int) (map[int]*Comic, map[int]error) {
+	return cos.GetManyContext(context.Background(), comicIDs, workers)
+}
+
+// GetManyContext is like GetMany, but the requests are sent using ctx.
+func (cos *ComicService) GetManyContext(ctx context.Context, comicIDs []int, workers int) (map[int]*Comic, map[int]error) {
+	values, errs := getMany(ctx, comicIDs, workers, func(ctx context.Context, id int) (interface{}, error) {
+		return cos.GetContext(ctx, id)
+	})
+	comics := make(map[int]*Comic, len(values))
+	for id, v := range values {
+		comics[id] = v.(*Comic)
+	}
+	return comics, errs
+}
+
 // CharactersWrapped returns all characters involving the given comic and match the
 // query parameters. The character slice will be encapsulated by CharacterDataContainer
 // and CharacterDataWrapper.
 func (cos *ComicService) CharactersWrapped(comicID int, params *CharacterParams) (*CharacterDataWrapper, *http.Response, error) {
+	return cos.CharactersWrappedContext(context.Background(), comicID, params)
+}
+
+// CharactersWrappedContext is like CharactersWrapped, but the request is sent using ctx.
+func (cos *ComicService) CharactersWrappedContext(ctx context.Context, comicID int, params *CharacterParams) (*CharacterDataWrapper, *http.Response, error) {
 	wrap := &CharacterDataWrapper{}
-	resp, err := receiveWrapped(cos.sling, fmt.Sprintf("%d/characters", comicID), wrap, params)
+	resp, err := receiveWrapped(ctx, cos.sling, fmt.Sprintf("%d/characters", comicID), wrap, params)
 	return wrap, resp, err
 }
 
 // Characters returns all characters involving the given comic and match the query parameters.
 func (cos *ComicService) Characters(comicID int, params *CharacterParams) ([]Character, error) {
-	wrap, _, err := cos.CharactersWrapped(comicID, params)
-	return wrap.Data.Results, err
+	return cos.CharactersContext(context.Background(), comicID, params)
+}
+
+// CharactersContext is like Characters, but the request is sent using ctx.
+func (cos *ComicService) CharactersContext(ctx context.Context, comicID int, params *CharacterParams) ([]Character, error) {
+	wrap, _, err := cos.CharactersWrappedContext(ctx, comicID, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
 }
 
 // CreatorsWrapped returns all creators involving the given comic and match the
 // query parameters. The creator slice will be encapsulated by CreatorDataContainer
 // and CreatorDataWrapper.
 func (cos *ComicService) CreatorsWrapped(comicID int, params *CreatorParams) (*CreatorDataWrapper, *http.Response, error) {
+	return cos.CreatorsWrappedContext(context.Background(), comicID, params)
+}
+
+// CreatorsWrappedContext is like CreatorsWrapped, but the request is sent using ctx.
+func (cos *ComicService) CreatorsWrappedContext(ctx context.Context, comicID int, params *CreatorParams) (*CreatorDataWrapper, *http.Response, error) {
 	wrap := &CreatorDataWrapper{}
-	resp, err := receiveWrapped(cos.sling, fmt.Sprintf("%d/creators", comicID), wrap, params)
+	resp, err := receiveWrapped(ctx, cos.sling, fmt.Sprintf("%d/creators", comicID), wrap, params)
 	return wrap, resp, err
 }
 
 // Creators returns all creators involving the given comic and match the query parameters.
 func (cos *ComicService) Creators(comicID int, params *CreatorParams) ([]Creator, error) {
-	wrap, _, err := cos.CreatorsWrapped(comicID, params)
-	return wrap.Data.Results, err
+	return cos.CreatorsContext(context.Background(), comicID, params)
+}
+
+// CreatorsContext is like Creators, but the request is sent using ctx.
+func (cos *ComicService) CreatorsContext(ctx context.Context, comicID int, params *CreatorParams) ([]Creator, error) {
+	wrap, _, err := cos.CreatorsWrappedContext(ctx, comicID, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
 }
 
 // EventsWrapped returns all events involving the given comic and match the
 // query parameters. The event slice will be encapsulated by EventDataContainer
 // and EventDataWrapper.
 func (cos *ComicService) EventsWrapped(comicID int, params *EventParams) (*EventDataWrapper, *http.Response, error) {
+	return cos.EventsWrappedContext(context.Background(), comicID, params)
+}
+
+// EventsWrappedContext is like EventsWrapped, but the request is sent using ctx.
+func (cos *ComicService) EventsWrappedContext(ctx context.Context, comicID int, params *EventParams) (*EventDataWrapper, *http.Response, error) {
 	wrap := &EventDataWrapper{}
-	resp, err := receiveWrapped(cos.sling, fmt.Sprintf("%d/events", comicID), wrap, params)
+	resp, err := receiveWrapped(ctx, cos.sling, fmt.Sprintf("%d/events", comicID), wrap, params)
 	return wrap, resp, err
 }
 
 // Events returns all events involving the given comic and match the query parameters.
 func (cos *ComicService) Events(comicID int, params *EventParams) ([]Event, error) {
-	wrap, _, err := cos.EventsWrapped(comicID, params)
-	return wrap.Data.Results, err
+	return cos.EventsContext(context.Background(), comicID, params)
+}
+
+// EventsContext is like Events, but the request is sent using ctx.
+func (cos *ComicService) EventsContext(ctx context.Context, comicID int, params *EventParams) ([]Event, error) {
+	wrap, _, err := cos.EventsWrappedContext(ctx, comicID, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
 }
 
 // StoriesWrapped returns all stories involving the given comic and match the
 // query parameters. The event slice will be encapsulated by StoryDataContainer
 // and StoryDataWrapper.
 func (cos *ComicService) StoriesWrapped(comicID int, params *StoryParams) (*StoryDataWrapper, *http.Response, error) {
+	return cos.StoriesWrappedContext(context.Background(), comicID, params)
+}
+
+// StoriesWrappedContext is like StoriesWrapped, but the request is sent using ctx.
+func (cos *ComicService) StoriesWrappedContext(ctx context.Context, comicID int, params *StoryParams) (*StoryDataWrapper, *http.Response, error) {
 	wrap := &StoryDataWrapper{}
-	resp, err := receiveWrapped(cos.sling, fmt.Sprintf("%d/stories", comicID), wrap, params)
+	resp, err := receiveWrapped(ctx, cos.sling, fmt.Sprintf("%d/stories", comicID), wrap, params)
 	return wrap, resp, err
 }
 
 // Stories returns all stories involving the given comic and match the query parameters.
 func (cos *ComicService) Stories(comicID int, params *StoryParams) ([]Story, error) {
-	wrap, _, err := cos.StoriesWrapped(comicID, params)
-	return wrap.Data.Results, err
+	return cos.StoriesContext(context.Background(), comicID, params)
+}
+
+// StoriesContext is like Stories, but the request is sent using ctx.
+func (cos *ComicService) StoriesContext(ctx context.Context, comicID int, params *StoryParams) ([]Story, error) {
+	wrap, _, err := cos.StoriesWrappedContext(ctx, comicID, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
+}
+
+// AllIter returns a ComicIterator over all comics that match the query
+// parameters, regardless of how many pages of results there are.
+func (cos *ComicService) AllIter(params *ComicParams) *ComicIterator {
+	return cos.AllIterContext(context.Background(), params)
+}
+
+// AllIterContext is like AllIter, but the requests are sent using ctx.
+func (cos *ComicService) AllIterContext(ctx context.Context, params *ComicParams) *ComicIterator {
+	return NewComicIterator(ctx, params, cos.AllWrappedContext)
+}
+
+// Walk calls fn for all comics that match the query parameters, regardless
+// of how many pages of results there are.
+func (cos *ComicService) Walk(params *ComicParams, fn func(Comic) error) error {
+	return cos.WalkContext(context.Background(), params, fn)
+}
+
+// WalkContext is like Walk, but the requests are sent using ctx.
+func (cos *ComicService) WalkContext(ctx context.Context, params *ComicParams, fn func(Comic) error) error {
+	return cos.AllIterContext(ctx, params).Walk(fn)
+}
+
+// CharactersIter returns a CharacterIterator over all characters involving the given
+// comic and match the query parameters, regardless of how many pages of
+// results there are.
+func (cos *ComicService) CharactersIter(comicID int, params *CharacterParams) *CharacterIterator {
+	return cos.CharactersIterContext(context.Background(), comicID, params)
+}
+
+// CharactersIterContext is like CharactersIter, but the requests are sent using ctx.
+func (cos *ComicService) CharactersIterContext(ctx context.Context, comicID int, params *CharacterParams) *CharacterIterator {
+	return NewCharacterIterator(ctx, params, func(ctx context.Context, params *CharacterParams) (*CharacterDataWrapper, *http.Response, error) {
+		return cos.CharactersWrappedContext(ctx, comicID, params)
+	})
+}
+
+// CreatorsIter returns a CreatorIterator over all creators involving the given
+// comic and match the query parameters, regardless of how many pages of
+// results there are.
+func (cos *ComicService) CreatorsIter(comicID int, params *CreatorParams) *CreatorIterator {
+	return cos.CreatorsIterContext(context.Background(), comicID, params)
+}
+
+// CreatorsIterContext is like CreatorsIter, but the requests are sent using ctx.
+func (cos *ComicService) CreatorsIterContext(ctx context.Context, comicID int, params *CreatorParams) *CreatorIterator {
+	return NewCreatorIterator(ctx, params, func(ctx context.Context, params *CreatorParams) (*CreatorDataWrapper, *http.Response, error) {
+		return cos.CreatorsWrappedContext(ctx, comicID, params)
+	})
+}
+
+// EventsIter returns an EventIterator over all events involving the given
+// comic and match the query parameters, regardless of how many pages of
+// results there are.
+func (cos *ComicService) EventsIter(comicID int, params *EventParams) *EventIterator {
+	return cos.EventsIterContext(context.Background(), comicID, params)
+}
+
+// EventsIterContext is like EventsIter, but the requests are sent using ctx.
+func (cos *ComicService) EventsIterContext(ctx context.Context, comicID int, params *EventParams) *EventIterator {
+	return NewEventIterator(ctx, params, func(ctx context.Context, params *EventParams) (*EventDataWrapper, *http.Response, error) {
+		return cos.EventsWrappedContext(ctx, comicID, params)
+	})
+}
+
+// StoriesIter returns a StoryIterator over all stories involving the given
+// comic and match the query parameters, regardless of how many pages of
+// results there are.
+func (cos *ComicService) StoriesIter(comicID int, params *StoryParams) *StoryIterator {
+	return cos.StoriesIterContext(context.Background(), comicID, params)
+}
+
+// StoriesIterContext is like StoriesIter, but the requests are sent using ctx.
+func (cos *ComicService) StoriesIterContext(ctx context.Context, comicID int, params *StoryParams) *StoryIterator {
+	return NewStoryIterator(ctx, params, func(ctx context.Context, params *StoryParams) (*StoryDataWrapper, *http.Response, error) {
+		return cos.StoriesWrappedContext(ctx, comicID, params)
+	})
 }
 
 // ComicDataWrapper provides comic wrapper information returned by the API.
@@ -159,33 +336,177 @@ type Comic struct {
 // ComicParams are optional parameters to narrow the comic results returned
 // by the API, as well as specify the number and order.
 type ComicParams struct {
-	Format            string      `url:"format,omitempty"`
-	FormatType        string      `url:"formatType,omitempty"`
-	NoVariants        bool        `url:"noVariants,omitempty"`
-	DateDescriptor    string      `url:"dateDescriptor,omitempty"`
-	DateRange         []time.Time `url:"dateRange,omitempty"`
-	Title             string      `url:"title,omitempty"`
-	TitleStartsWith   string      `url:"titleStartsWith,omitempty"`
-	StartYear         int         `url:"startYear,omitempty"`
-	IssueNumber       int         `url:"issueNumber,omitempty"`
-	DiamondCode       string      `url:"diamondCode,omitempty"`
-	DigitalID         int         `url:"digitalId,omitempty"`
-	UPC               string      `url:"upc,omitempty"`
-	ISBN              string      `url:"isbn,omitempty"`
-	EAN               string      `url:"ean,omitempty"`
-	ISSN              string      `url:"issn,omitempty"`
-	HasDigitalIssue   bool        `url:"hasDigitalIssue,omitempty"`
-	ModifiedSince     time.Time   `url:"modifiedSince,omitempty"`
-	Creators          []int       `url:"creators,omitempty"`
-	Characters        []int       `url:"characters,omitempty"`
-	Series            []int       `url:"series,omitempty"`
-	Events            []int       `url:"events,omitempty"`
-	Stories           []int       `url:"stories,omitempty"`
-	SharedAppearances []int       `url:"sharedAppearances,omitempty"`
-	Collaborators     []int       `url:"collaborators,omitempty"`
-	OrderBy           string      `url:"orderBy,omitempty"`
-	Limit             int         `url:"limit,omitempty"`
-	Offset            int         `url:"offset,omitempty"`
+	Format            Format         `url:"format,omitempty"`
+	FormatType        FormatType     `url:"formatType,omitempty"`
+	NoVariants        bool           `url:"noVariants,omitempty"`
+	DateDescriptor    DateDescriptor `url:"dateDescriptor,omitempty"`
+	DateRange         DateRange      `url:"dateRange,omitempty"`
+	Title             string         `url:"title,omitempty"`
+	TitleStartsWith   string         `url:"titleStartsWith,omitempty"`
+	StartYear         int            `url:"startYear,omitempty"`
+	IssueNumber       int            `url:"issueNumber,omitempty"`
+	DiamondCode       string         `url:"diamondCode,omitempty"`
+	DigitalID         int            `url:"digitalId,omitempty"`
+	UPC               string         `url:"upc,omitempty"`
+	ISBN              string         `url:"isbn,omitempty"`
+	EAN               string         `url:"ean,omitempty"`
+	ISSN              string         `url:"issn,omitempty"`
+	HasDigitalIssue   bool           `url:"hasDigitalIssue,omitempty"`
+	ModifiedSince     time.Time      `url:"modifiedSince,omitempty" layout:"2006-01-02T15:04:05-0700"`
+	Creators          IDList         `url:"creators,omitempty"`
+	Characters        IDList         `url:"characters,omitempty"`
+	Series            IDList         `url:"series,omitempty"`
+	Events            IDList         `url:"events,omitempty"`
+	Stories           IDList         `url:"stories,omitempty"`
+	SharedAppearances IDList         `url:"sharedAppearances,omitempty"`
+	Collaborators     IDList         `url:"collaborators,omitempty"`
+	OrderBy           ComicOrderBy   `url:"orderBy,omitempty"`
+	Limit             int            `url:"limit,omitempty"`
+	Offset            int            `url:"offset,omitempty"`
+}
+
+// ComicOrderBy is the order of a list of comics. Orders may be joined with
+// commas, in decreasing priority, e.g., "focDate,-modified".
+type ComicOrderBy string
+
+// The orders in which comics can be listed. The Desc variants sort in
+// descending order.
+const (
+	ComicOrderByFOCDate         ComicOrderBy = "focDate"
+	ComicOrderByFOCDateDesc     ComicOrderBy = "-focDate"
+	ComicOrderByOnSaleDate      ComicOrderBy = "onsaleDate"
+	ComicOrderByOnSaleDateDesc  ComicOrderBy = "-onsaleDate"
+	ComicOrderByTitle           ComicOrderBy = "title"
+	ComicOrderByTitleDesc       ComicOrderBy = "-title"
+	ComicOrderByIssueNumber     ComicOrderBy = "issueNumber"
+	ComicOrderByIssueNumberDesc ComicOrderBy = "-issueNumber"
+	ComicOrderByModified        ComicOrderBy = "modified"
+	ComicOrderByModifiedDesc    ComicOrderBy = "-modified"
+)
+
+// valid reports whether the API accepts the order.
+func (o ComicOrderBy) valid() bool {
+	return validOrder(string(o), "focDate", "onsaleDate", "title", "issueNumber", "modified")
+}
+
+// Validate returns a *ValidationError listing each of the parameters the API
+// would reject. It is called before each request is sent with the parameters.
+func (p *ComicParams) Validate() error {
+	if p == nil {
+		return nil
+	}
+	v := newValidation("ComicParams")
+	v.enum(p.Format.valid(), "Format", string(p.Format))
+	v.enum(p.FormatType.valid(), "FormatType", string(p.FormatType))
+	v.enum(p.DateDescriptor.valid(), "DateDescriptor", string(p.DateDescriptor))
+	v.dateRange("DateRange", p.DateRange)
+	v.text("Title", p.Title)
+	v.text("TitleStartsWith", p.TitleStartsWith)
+	v.text("DiamondCode", p.DiamondCode)
+	v.text("UPC", p.UPC)
+	v.text("ISBN", p.ISBN)
+	v.text("EAN", p.EAN)
+	v.text("ISSN", p.ISSN)
+	v.ids("Creators", p.Creators)
+	v.ids("Characters", p.Characters)
+	v.ids("Series", p.Series)
+	v.ids("Events", p.Events)
+	v.ids("Stories", p.Stories)
+	v.ids("SharedAppearances", p.SharedAppearances)
+	v.ids("Collaborators", p.Collaborators)
+	v.enum(p.OrderBy.valid(), "OrderBy", string(p.OrderBy))
+	v.page(p.Limit, p.Offset)
+	return v.err()
+}
+
+// Format is the publication format of a comic.
+type Format string
+
+// The formats in which comics are published.
+const (
+	FormatComic          Format = "comic"
+	FormatMagazine       Format = "magazine"
+	FormatTradePaperback Format = "trade paperback"
+	FormatHardcover      Format = "hardcover"
+	FormatDigest         Format = "digest"
+	FormatGraphicNovel   Format = "graphic novel"
+	FormatDigitalComic   Format = "digital comic"
+	FormatInfiniteComic  Format = "infinite comic"
+)
+
+// formats are the values of all the Format constants.
+var formats = []string{
+	string(FormatComic), string(FormatMagazine), string(FormatTradePaperback),
+	string(FormatHardcover), string(FormatDigest), string(FormatGraphicNovel),
+	string(FormatDigitalComic), string(FormatInfiniteComic),
+}
+
+// valid reports whether the API accepts the format.
+func (f Format) valid() bool {
+	return oneOf(string(f), formats...)
+}
+
+// FormatList is a list of formats used to filter results, e.g.,
+// SeriesParams.Contains. It is sent comma-separated, e.g.,
+// "contains=comic,digest".
+type FormatList []Format
+
+// String returns the list as it is sent to the API.
+func (fl FormatList) String() string {
+	strs := make([]string, len(fl))
+	for i, f := range fl {
+		strs[i] = string(f)
+	}
+	return strings.Join(strs, ",")
+}
+
+// EncodeValues implements the query.Encoder interface.
+func (fl FormatList) EncodeValues(key string, v *url.Values) error {
+	if len(fl) > 0 {
+		v.Set(key, fl.String())
+	}
+	return nil
+}
+
+// valid reports whether the API accepts each of the formats.
+func (fl FormatList) valid() bool {
+	for _, f := range fl {
+		if f == "" || !f.valid() {
+			return false
+		}
+	}
+	return true
+}
+
+// FormatType distinguishes single issues from collections of them.
+type FormatType string
+
+// The types of comic formats.
+const (
+	FormatTypeComic      FormatType = "comic"
+	FormatTypeCollection FormatType = "collection"
+)
+
+// valid reports whether the API accepts the format type.
+func (ft FormatType) valid() bool {
+	return oneOf(string(ft), string(FormatTypeComic), string(FormatTypeCollection))
+}
+
+// DateDescriptor is a predefined range of comic on-sale dates.
+type DateDescriptor string
+
+// The predefined ranges of on-sale dates.
+const (
+	DateDescriptorLastWeek  DateDescriptor = "lastWeek"
+	DateDescriptorThisWeek  DateDescriptor = "thisWeek"
+	DateDescriptorNextWeek  DateDescriptor = "nextWeek"
+	DateDescriptorThisMonth DateDescriptor = "thisMonth"
+)
+
+// valid reports whether the API accepts the date descriptor.
+func (dd DateDescriptor) valid() bool {
+	return oneOf(string(dd), string(DateDescriptorLastWeek), string(DateDescriptorThisWeek),
+		string(DateDescriptorNextWeek), string(DateDescriptorThisMonth))
 }
 
 // ComicDate represents a moment of importance for the comic.
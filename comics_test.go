@@ -62,7 +62,7 @@ func TestComicsAllDateRange(t *testing.T) {
 
 	sDate := time.Date(2016, time.August, 17, 17, 46, 57, 123, time.UTC)
 	eDate := time.Date(2016, time.September, 17, 17, 46, 57, 123, time.UTC)
-	params := &marvel.ComicParams{DateRange: []time.Time{sDate, eDate}}
+	params := &marvel.ComicParams{DateRange: marvel.DateRange{Start: sDate, End: eDate}}
 	comics, err := c.Comics.All(params)
 	assert.NoError(t, err, "Comics.All({}) returned an error")
 	assert.Equal(t, 57387, comics[0].ID, "Incorrect ID")
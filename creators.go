@@ -1,6 +1,7 @@
 package marvel
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -23,92 +24,266 @@ func NewCreatorService(sling *sling.Sling) *CreatorService {
 // AllWrapped returns all creators that match the query parameters. The creator
 // slice will be encapsulated by CreatorDataContainer and CreatorDataWrapper.
 func (ctrs *CreatorService) AllWrapped(params *CreatorParams) (*CreatorDataWrapper, *http.Response, error) {
+	return ctrs.AllWrappedContext(context.Background(), params)
+}
+
+// AllWrappedContext is like AllWrapped, but the request is sent using ctx.
+func (ctrs *CreatorService) AllWrappedContext(ctx context.Context, params *CreatorParams) (*CreatorDataWrapper, *http.Response, error) {
 	wrap := &CreatorDataWrapper{}
-	resp, err := receiveWrapped(ctrs.sling, "../creators", wrap, params)
+	resp, err := receiveWrapped(ctx, ctrs.sling, "../creators", wrap, params)
 	return wrap, resp, err
 }
 
 // All returns all creators that match the query parameters.
 func (ctrs *CreatorService) All(params *CreatorParams) ([]Creator, error) {
-	wrap, _, err := ctrs.AllWrapped(params)
-	return wrap.Data.Results, err
+	return ctrs.AllContext(context.Background(), params)
+}
+
+// AllContext is like All, but the request is sent using ctx.
+func (ctrs *CreatorService) AllContext(ctx context.Context, params *CreatorParams) ([]Creator, error) {
+	wrap, _, err := ctrs.AllWrappedContext(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
 }
 
 // GetWrapped returns the creator associated with the given ID. The creator
 // details will be encapsulated by CreatorDataContainer and CreatorDataWrapper.
 func (ctrs *CreatorService) GetWrapped(creatorID int) (*CreatorDataWrapper, *http.Response, error) {
+	return ctrs.GetWrappedContext(context.Background(), creatorID)
+}
+
+// GetWrappedContext is like GetWrapped, but the request is sent using ctx.
+func (ctrs *CreatorService) GetWrappedContext(ctx context.Context, creatorID int) (*CreatorDataWrapper, *http.Response, error) {
 	wrap := &CreatorDataWrapper{}
-	resp, err := receiveWrapped(ctrs.sling, fmt.Sprintf("%d", creatorID), wrap, nil)
+	resp, err := receiveWrapped(ctx, ctrs.sling, fmt.Sprintf("%d", creatorID), wrap, nil)
 	return wrap, resp, err
 }
 
-// Get returns the creator associated with the given ID.
+// Get returns the creator associated with the given ID. A NotFoundError is
+// returned if there is no such creator.
 func (ctrs *CreatorService) Get(creatorID int) (*Creator, error) {
-	wrap, _, err := ctrs.GetWrapped(creatorID)
-	if err != nil {
+	return ctrs.GetContext(context.Background(), creatorID)
+}
+
+// GetContext is like Get, but the request is sent using ctx.
+func (ctrs *CreatorService) GetContext(ctx context.Context, creatorID int) (*Creator, error) {
+	wrap, _, err := ctrs.GetWrappedContext(ctx, creatorID)
+	if err = getError(CreatorsResource, creatorID, err, len(wrap.Data.Results)); err != nil {
 		return nil, err
 	}
 	return &wrap.Data.Results[0], nil
 }
 
+// GetMany returns the creators associated with the given IDs, keyed by ID. The
+// creators are requested concurrently, at most workers at a time, and any
+// errors are returned keyed by the ID that caused them. A workers value less
+// than 1 is treated as 1.
+func (ctrs *CreatorService) GetMany(creatorIDs []int, workers int) (map[int]*Creator, map[int]error) {
+	return ctrs.GetManyContext(context.Background(), creatorIDs, workers)
+}
+
+// GetManyContext is like GetMany, but the requests are sent using ctx.
+func (ctrs *CreatorService) GetManyContext(ctx context.Context, creatorIDs []int, workers int) (map[int]*Creator, map[int]error) {
+	values, errs := getMany(ctx, creatorIDs, workers, func(ctx context.Context, id int) (interface{}, error) {
+		return ctrs.GetContext(ctx, id)
+	})
+	creators := make(map[int]*Creator, len(values))
+	for id, v := range values {
+		creators[id] = v.(*Creator)
+	}
+	return creators, errs
+}
+
 // ComicsWrapped returns all comics involving the given creator and match the
 // query parameters. The comic slice will be encapsulated by ComicDataContainer
 // and ComicDataWrapper.
 func (ctrs *CreatorService) ComicsWrapped(creatorID int, params *ComicParams) (*ComicDataWrapper, *http.Response, error) {
+	return ctrs.ComicsWrappedContext(context.Background(), creatorID, params)
+}
+
+// ComicsWrappedContext is like ComicsWrapped, but the request is sent using ctx.
+func (ctrs *CreatorService) ComicsWrappedContext(ctx context.Context, creatorID int, params *ComicParams) (*ComicDataWrapper, *http.Response, error) {
 	wrap := &ComicDataWrapper{}
-	resp, err := receiveWrapped(ctrs.sling, fmt.Sprintf("%d/comics", creatorID), wrap, params)
+	resp, err := receiveWrapped(ctx, ctrs.sling, fmt.Sprintf("%d/comics", creatorID), wrap, params)
 	return wrap, resp, err
 }
 
 // Comics returns all comics involving the given creator and match the query parameters.
 func (ctrs *CreatorService) Comics(creatorID int, params *ComicParams) ([]Comic, error) {
-	wrap, _, err := ctrs.ComicsWrapped(creatorID, params)
-	return wrap.Data.Results, err
+	return ctrs.ComicsContext(context.Background(), creatorID, params)
+}
+
+// ComicsContext is like Comics, but the request is sent using ctx.
+func (ctrs *CreatorService) ComicsContext(ctx context.Context, creatorID int, params *ComicParams) ([]Comic, error) {
+	wrap, _, err := ctrs.ComicsWrappedContext(ctx, creatorID, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
 }
 
 // EventsWrapped returns all events involving the given creator and match the
 // query parameters. The event slice will be encapsulated by EventDataContainer
 // and EventDataWrapper.
 func (ctrs *CreatorService) EventsWrapped(creatorID int, params *EventParams) (*EventDataWrapper, *http.Response, error) {
+	return ctrs.EventsWrappedContext(context.Background(), creatorID, params)
+}
+
+// EventsWrappedContext is like EventsWrapped, but the request is sent using ctx.
+func (ctrs *CreatorService) EventsWrappedContext(ctx context.Context, creatorID int, params *EventParams) (*EventDataWrapper, *http.Response, error) {
 	wrap := &EventDataWrapper{}
-	resp, err := receiveWrapped(ctrs.sling, fmt.Sprintf("%d/events", creatorID), wrap, params)
+	resp, err := receiveWrapped(ctx, ctrs.sling, fmt.Sprintf("%d/events", creatorID), wrap, params)
 	return wrap, resp, err
 }
 
 // Events returns all events involving the given creator and match the query parameters.
 func (ctrs *CreatorService) Events(creatorID int, params *EventParams) ([]Event, error) {
-	wrap, _, err := ctrs.EventsWrapped(creatorID, params)
-	return wrap.Data.Results, err
+	return ctrs.EventsContext(context.Background(), creatorID, params)
+}
+
+// EventsContext is like Events, but the request is sent using ctx.
+func (ctrs *CreatorService) EventsContext(ctx context.Context, creatorID int, params *EventParams) ([]Event, error) {
+	wrap, _, err := ctrs.EventsWrappedContext(ctx, creatorID, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
 }
 
 // SeriesWrapped returns all series involving the given creator and match the
 // query parameters. The series slice will be encapsulated by SeriesDataContainer
 // and SeriesDataWrapper.
 func (ctrs *CreatorService) SeriesWrapped(creatorID int, params *SeriesParams) (*SeriesDataWrapper, *http.Response, error) {
+	return ctrs.SeriesWrappedContext(context.Background(), creatorID, params)
+}
+
+// SeriesWrappedContext is like SeriesWrapped, but the request is sent using ctx.
+func (ctrs *CreatorService) SeriesWrappedContext(ctx context.Context, creatorID int, params *SeriesParams) (*SeriesDataWrapper, *http.Response, error) {
 	wrap := &SeriesDataWrapper{}
-	resp, err := receiveWrapped(ctrs.sling, fmt.Sprintf("%d/series", creatorID), wrap, params)
+	resp, err := receiveWrapped(ctx, ctrs.sling, fmt.Sprintf("%d/series", creatorID), wrap, params)
 	return wrap, resp, err
 }
 
 // Series returns all series involving the given creator and match the query parameters.
 func (ctrs *CreatorService) Series(creatorID int, params *SeriesParams) ([]Series, error) {
-	wrap, _, err := ctrs.SeriesWrapped(creatorID, params)
-	return wrap.Data.Results, err
+	return ctrs.SeriesContext(context.Background(), creatorID, params)
+}
+
+// SeriesContext is like Series, but the request is sent using ctx.
+func (ctrs *CreatorService) SeriesContext(ctx context.Context, creatorID int, params *SeriesParams) ([]Series, error) {
+	wrap, _, err := ctrs.SeriesWrappedContext(ctx, creatorID, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
 }
 
 // StoriesWrapped returns all stories involving the given creator and match the
 // query parameters. The story slice will be encapsulated by StoryDataContainer
 // and StoryDataWrapper.
 func (ctrs *CreatorService) StoriesWrapped(creatorID int, params *StoryParams) (*StoryDataWrapper, *http.Response, error) {
+	return ctrs.StoriesWrappedContext(context.Background(), creatorID, params)
+}
+
+// StoriesWrappedContext is like StoriesWrapped, but the request is sent using ctx.
+func (ctrs *CreatorService) StoriesWrappedContext(ctx context.Context, creatorID int, params *StoryParams) (*StoryDataWrapper, *http.Response, error) {
 	wrap := &StoryDataWrapper{}
-	resp, err := receiveWrapped(ctrs.sling, fmt.Sprintf("%d/stories", creatorID), wrap, params)
+	resp, err := receiveWrapped(ctx, ctrs.sling, fmt.Sprintf("%d/stories", creatorID), wrap, params)
 	return wrap, resp, err
 }
 
 // Stories returns all stories involving the given creator and match the query parameters.
 func (ctrs *CreatorService) Stories(creatorID int, params *StoryParams) ([]Story, error) {
-	wrap, _, err := ctrs.StoriesWrapped(creatorID, params)
-	return wrap.Data.Results, err
+	return ctrs.StoriesContext(context.Background(), creatorID, params)
+}
+
+// StoriesContext is like Stories, but the request is sent using ctx.
+func (ctrs *CreatorService) StoriesContext(ctx context.Context, creatorID int, params *StoryParams) ([]Story, error) {
+	wrap, _, err := ctrs.StoriesWrappedContext(ctx, creatorID, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
+}
+
+// AllIter returns a CreatorIterator over all creators that match the query
+// parameters, regardless of how many pages of results there are.
+func (ctrs *CreatorService) AllIter(params *CreatorParams) *CreatorIterator {
+	return ctrs.AllIterContext(context.Background(), params)
+}
+
+// AllIterContext is like AllIter, but the requests are sent using ctx.
+func (ctrs *CreatorService) AllIterContext(ctx context.Context, params *CreatorParams) *CreatorIterator {
+	return NewCreatorIterator(ctx, params, ctrs.AllWrappedContext)
+}
+
+// Walk calls fn for all creators that match the query parameters, regardless
+// of how many pages of results there are.
+func (ctrs *CreatorService) Walk(params *CreatorParams, fn func(Creator) error) error {
+	return ctrs.WalkContext(context.Background(), params, fn)
+}
+
+// WalkContext is like Walk, but the requests are sent using ctx.
+func (ctrs *CreatorService) WalkContext(ctx context.Context, params *CreatorParams, fn func(Creator) error) error {
+	return ctrs.AllIterContext(ctx, params).Walk(fn)
+}
+
+// ComicsIter returns a ComicIterator over all comics involving the given
+// creator and match the query parameters, regardless of how many pages of
+// results there are.
+func (ctrs *CreatorService) ComicsIter(creatorID int, params *ComicParams) *ComicIterator {
+	return ctrs.ComicsIterContext(context.Background(), creatorID, params)
+}
+
+// ComicsIterContext is like ComicsIter, but the requests are sent using ctx.
+func (ctrs *CreatorService) ComicsIterContext(ctx context.Context, creatorID int, params *ComicParams) *ComicIterator {
+	return NewComicIterator(ctx, params, func(ctx context.Context, params *ComicParams) (*ComicDataWrapper, *http.Response, error) {
+		return ctrs.ComicsWrappedContext(ctx, creatorID, params)
+	})
+}
+
+// EventsIter returns an EventIterator over all events involving the given
+// creator and match the query parameters, regardless of how many pages of
+// results there are.
+func (ctrs *CreatorService) EventsIter(creatorID int, params *EventParams) *EventIterator {
+	return ctrs.EventsIterContext(context.Background(), creatorID, params)
+}
+
+// EventsIterContext is like EventsIter, but the requests are sent using ctx.
+func (ctrs *CreatorService) EventsIterContext(ctx context.Context, creatorID int, params *EventParams) *EventIterator {
+	return NewEventIterator(ctx, params, func(ctx context.Context, params *EventParams) (*EventDataWrapper, *http.Response, error) {
+		return ctrs.EventsWrappedContext(ctx, creatorID, params)
+	})
+}
+
+// SeriesIter returns a SeriesIterator over all series involving the given
+// creator and match the query parameters, regardless of how many pages of
+// results there are.
+func (ctrs *CreatorService) SeriesIter(creatorID int, params *SeriesParams) *SeriesIterator {
+	return ctrs.SeriesIterContext(context.Background(), creatorID, params)
+}
+
+// SeriesIterContext is like SeriesIter, but the requests are sent using ctx.
+func (ctrs *CreatorService) SeriesIterContext(ctx context.Context, creatorID int, params *SeriesParams) *SeriesIterator {
+	return NewSeriesIterator(ctx, params, func(ctx context.Context, params *SeriesParams) (*SeriesDataWrapper, *http.Response, error) {
+		return ctrs.SeriesWrappedContext(ctx, creatorID, params)
+	})
+}
+
+// StoriesIter returns a StoryIterator over all stories involving the given
+// creator and match the query parameters, regardless of how many pages of
+// results there are.
+func (ctrs *CreatorService) StoriesIter(creatorID int, params *StoryParams) *StoryIterator {
+	return ctrs.StoriesIterContext(context.Background(), creatorID, params)
+}
+
+// StoriesIterContext is like StoriesIter, but the requests are sent using ctx.
+func (ctrs *CreatorService) StoriesIterContext(ctx context.Context, creatorID int, params *StoryParams) *StoryIterator {
+	return NewStoryIterator(ctx, params, func(ctx context.Context, params *StoryParams) (*StoryDataWrapper, *http.Response, error) {
+		return ctrs.StoriesWrappedContext(ctx, creatorID, params)
+	})
 }
 
 // CreatorDataWrapper provides creator wrapper information returned by the API.
@@ -144,22 +319,70 @@ type Creator struct {
 // CreatorParams are optional parameters to narrow the creator results returned
 // by the API, as well as specify the number and order.
 type CreatorParams struct {
-	FirstName            string    `url:"firstName,omitempty"`
-	MiddleName           string    `url:"middleName,omitempty"`
-	LastName             string    `url:"lastName,omitempty"`
-	Suffix               string    `url:"suffix,omitempty"`
-	NameStartsWith       string    `url:"nameStartsWith,omitempty"`
-	FirstNameStartsWith  string    `url:"firstNameStartsWith,omitempty"`
-	MiddleNameStartsWith string    `url:"middleNameStartsWith,omitempty"`
-	LastNameStartsWith   string    `url:"lastNameStartsWith,omitempty"`
-	ModifiedSince        time.Time `url:"modifiedSince,omitempty"`
-	Comics               []int     `url:"comics,omitempty"`
-	Series               []int     `url:"series,omitempty"`
-	Events               []int     `url:"events,omitempty"`
-	Stories              []int     `url:"stories,omitempty"`
-	OrderBy              string    `url:"orderBy,omitempty"`
-	Limit                int       `url:"limit,omitempty"`
-	Offset               int       `url:"offset,omitempty"`
+	FirstName            string         `url:"firstName,omitempty"`
+	MiddleName           string         `url:"middleName,omitempty"`
+	LastName             string         `url:"lastName,omitempty"`
+	Suffix               string         `url:"suffix,omitempty"`
+	NameStartsWith       string         `url:"nameStartsWith,omitempty"`
+	FirstNameStartsWith  string         `url:"firstNameStartsWith,omitempty"`
+	MiddleNameStartsWith string         `url:"middleNameStartsWith,omitempty"`
+	LastNameStartsWith   string         `url:"lastNameStartsWith,omitempty"`
+	ModifiedSince        time.Time      `url:"modifiedSince,omitempty" layout:"2006-01-02T15:04:05-0700"`
+	Comics               IDList         `url:"comics,omitempty"`
+	Series               IDList         `url:"series,omitempty"`
+	Events               IDList         `url:"events,omitempty"`
+	Stories              IDList         `url:"stories,omitempty"`
+	OrderBy              CreatorOrderBy `url:"orderBy,omitempty"`
+	Limit                int            `url:"limit,omitempty"`
+	Offset               int            `url:"offset,omitempty"`
+}
+
+// CreatorOrderBy is the order of a list of creators. Orders may be joined with
+// commas, in decreasing priority, e.g., "lastName,-modified".
+type CreatorOrderBy string
+
+// The orders in which creators can be listed. The Desc variants sort in
+// descending order.
+const (
+	CreatorOrderByLastName       CreatorOrderBy = "lastName"
+	CreatorOrderByLastNameDesc   CreatorOrderBy = "-lastName"
+	CreatorOrderByFirstName      CreatorOrderBy = "firstName"
+	CreatorOrderByFirstNameDesc  CreatorOrderBy = "-firstName"
+	CreatorOrderByMiddleName     CreatorOrderBy = "middleName"
+	CreatorOrderByMiddleNameDesc CreatorOrderBy = "-middleName"
+	CreatorOrderBySuffix         CreatorOrderBy = "suffix"
+	CreatorOrderBySuffixDesc     CreatorOrderBy = "-suffix"
+	CreatorOrderByModified       CreatorOrderBy = "modified"
+	CreatorOrderByModifiedDesc   CreatorOrderBy = "-modified"
+)
+
+// valid reports whether the API accepts the order.
+func (o CreatorOrderBy) valid() bool {
+	return validOrder(string(o), "lastName", "firstName", "middleName", "suffix", "modified")
+}
+
+// Validate returns a *ValidationError listing each of the parameters the API
+// would reject. It is called before each request is sent with the parameters.
+func (p *CreatorParams) Validate() error {
+	if p == nil {
+		return nil
+	}
+	v := newValidation("CreatorParams")
+	v.text("FirstName", p.FirstName)
+	v.text("MiddleName", p.MiddleName)
+	v.text("LastName", p.LastName)
+	v.text("Suffix", p.Suffix)
+	v.text("NameStartsWith", p.NameStartsWith)
+	v.text("FirstNameStartsWith", p.FirstNameStartsWith)
+	v.text("MiddleNameStartsWith", p.MiddleNameStartsWith)
+	v.text("LastNameStartsWith", p.LastNameStartsWith)
+	v.ids("Comics", p.Comics)
+	v.ids("Series", p.Series)
+	v.ids("Events", p.Events)
+	v.ids("Stories", p.Stories)
+	v.enum(p.OrderBy.valid(), "OrderBy", string(p.OrderBy))
+	v.page(p.Limit, p.Offset)
+	return v.err()
 }
 
 // CreatorList provides creators related to the parent entity.
@@ -1,6 +1,9 @@
 package marvel
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -53,6 +56,12 @@ func (tm *Time) UnmarshalJSON(b []byte) (err error) {
 	return
 }
 
+// MarshalJSON implements the json.Marshaler interface. Times are written in the
+// format of the API's timestamps, which UnmarshalJSON reads back.
+func (tm Time) MarshalJSON() ([]byte, error) {
+	return []byte(tm.Format(`"2006-01-02T15:04:05-0700"`)), nil
+}
+
 // TextObject represents a descriptive text blurb for the parent entity.
 type TextObject struct {
 	Type     string `json:"type,omitempty"`
@@ -74,3 +83,14 @@ type Summary struct {
 	ResourceURI string `json:"resourceURI,omitempty"`
 	Name        string `json:"name,omitempty"`
 }
+
+// ID returns the ID of the summarized entity, parsed from the end of its
+// ResourceURI.
+func (s Summary) ID() (int, error) {
+	idStr := s.ResourceURI[strings.LastIndex(s.ResourceURI, "/")+1:]
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("marvel: no ID at end of resource URI %q", s.ResourceURI)
+	}
+	return id, nil
+}
@@ -0,0 +1,174 @@
+package marvel
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// The kinds of error returned by the API. An APIError matches the appropriate
+// kind when using errors.Is, e.g., errors.Is(err, ErrNotFound).
+var (
+	ErrInvalidCredentials = errors.New("marvel: invalid credentials")
+	ErrRateLimited        = errors.New("marvel: rate limited")
+	ErrInvalidParameter   = errors.New("marvel: invalid parameter")
+	ErrNotFound           = errors.New("marvel: not found")
+)
+
+// ErrorCode is the code of an APIError. Authentication errors have descriptive
+// codes, e.g., "InvalidCredentials", while usage errors have numeric codes, e.g.,
+// "409".
+type ErrorCode string
+
+// The descriptive codes of authentication errors returned by the API.
+const (
+	CodeInvalidCredentials ErrorCode = "InvalidCredentials"
+	CodeInvalidHash        ErrorCode = "InvalidHash"
+	CodeInvalidReferer     ErrorCode = "InvalidReferer"
+	CodeMissingParameter   ErrorCode = "MissingParameter"
+	CodeRequestThrottled   ErrorCode = "RequestThrottled"
+	CodeForbidden          ErrorCode = "Forbidden"
+	CodeMethodNotAllowed   ErrorCode = "MethodNotAllowed"
+)
+
+// UnmarshalJSON implements the json.Unmarshaler interface. The API gives codes as
+// either strings or integers.
+func (ec *ErrorCode) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*ec = ErrorCode(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		return err
+	}
+	*ec = ErrorCode(n.String())
+	return nil
+}
+
+// APIError is the error, if any, returned by the service. Authentication error
+// responses will have a descriptive Code, e.g., CodeInvalidCredentials. For
+// usage errors otherwise, Code will be numeric, e.g., "409".
+type APIError struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+	// Status holds the error's description when the API gives it in place of
+	// Message.
+	Status string `json:"status"`
+
+	// StatusCode is the HTTP status of the response.
+	StatusCode int `json:"-"`
+	// URL is the request URL, without authentication parameters.
+	URL string `json:"-"`
+	// Body holds the response body when it is not JSON, e.g., an HTML page
+	// from a gateway.
+	Body string `json:"-"`
+}
+
+// Error implements the Error interface.
+func (ae *APIError) Error() string {
+	msg := ae.Message
+	if msg == "" {
+		msg = ae.Status
+	}
+	if msg == "" {
+		msg = http.StatusText(ae.StatusCode)
+	}
+	return fmt.Sprintf("marvel: %v %v", ae.Code, msg)
+}
+
+// Is reports whether the error is of the given kind, e.g., ErrNotFound.
+func (ae *APIError) Is(target error) bool {
+	switch target {
+	case ErrInvalidCredentials:
+		return ae.Code == CodeInvalidCredentials || ae.Code == CodeInvalidHash ||
+			ae.Code == CodeInvalidReferer || ae.StatusCode == http.StatusUnauthorized
+	case ErrRateLimited:
+		return ae.Code == CodeRequestThrottled || ae.StatusCode == http.StatusTooManyRequests
+	case ErrInvalidParameter:
+		return ae.Code == CodeMissingParameter || ae.Code == "409" ||
+			ae.StatusCode == http.StatusConflict
+	case ErrNotFound:
+		return ae.Code == "404" || ae.StatusCode == http.StatusNotFound
+	}
+	return false
+}
+
+// complete fills in the details of the response the error was decoded from.
+// Responses without a code, e.g., those without a JSON body, are given their
+// HTTP status as code.
+func (ae *APIError) complete(statusCode int, url string) {
+	ae.StatusCode = statusCode
+	ae.URL = url
+	if ae.Code == "" {
+		ae.Code = ErrorCode(strconv.Itoa(statusCode))
+	}
+}
+
+// maxErrorBody is the most of a non-JSON error body kept by an APIError.
+const maxErrorBody = 4096
+
+// responseDecoder decodes JSON responses. Error responses which are not JSON
+// are kept as the APIError's Body rather than failing to decode.
+type responseDecoder struct{}
+
+// Decode implements the sling.ResponseDecoder interface.
+func (rd responseDecoder) Decode(resp *http.Response, v interface{}) error {
+	apiErr, ok := v.(*APIError)
+	if !ok {
+		return json.NewDecoder(resp.Body).Decode(v)
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	if err != nil {
+		return err
+	}
+	if json.Unmarshal(body, apiErr) != nil {
+		*apiErr = APIError{Body: strings.TrimSpace(string(body))}
+	}
+	return nil
+}
+
+// NotFoundError is returned when the entity requested by ID does not exist,
+// either because the API replied 404 Not Found or because it returned no
+// results. It matches ErrNotFound when using errors.Is.
+type NotFoundError struct {
+	Resource Resource
+	ID       int
+	// Err is the APIError of a 404 response, if there was one.
+	Err error
+}
+
+// Error implements the Error interface.
+func (nfe *NotFoundError) Error() string {
+	return fmt.Sprintf("marvel: %s/%d not found", nfe.Resource, nfe.ID)
+}
+
+// Is reports whether target is ErrNotFound.
+func (nfe *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// Unwrap returns the APIError of a 404 response, if any.
+func (nfe *NotFoundError) Unwrap() error {
+	return nfe.Err
+}
+
+// getError returns the error, if any, for getting a single entity. A 404 error
+// or a response with no results is returned as a NotFoundError.
+func getError(resource Resource, id int, err error, count int) error {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return &NotFoundError{Resource: resource, ID: id, Err: err}
+	case err != nil:
+		return err
+	case count == 0:
+		return &NotFoundError{Resource: resource, ID: id}
+	}
+	return nil
+}
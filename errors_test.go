@@ -0,0 +1,231 @@
+package marvel_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/dustinrc/marvel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIErrorKinds(t *testing.T) {
+	kinds := []error{
+		marvel.ErrInvalidCredentials,
+		marvel.ErrRateLimited,
+		marvel.ErrInvalidParameter,
+		marvel.ErrNotFound,
+	}
+	testCases := []struct {
+		desc   string
+		status int
+		body   string
+		kind   error
+		code   marvel.ErrorCode
+		eMsg   string
+		eBody  string
+	}{
+		{
+			desc:   "invalid credentials",
+			status: 401,
+			body:   `{"code": "InvalidCredentials", "message": "The passed API key is invalid."}`,
+			kind:   marvel.ErrInvalidCredentials,
+			code:   marvel.CodeInvalidCredentials,
+			eMsg:   "marvel: InvalidCredentials The passed API key is invalid.",
+		},
+		{
+			desc:   "invalid hash",
+			status: 401,
+			body:   `{"code": "InvalidHash", "message": "That hash, timestamp and key combination is invalid."}`,
+			kind:   marvel.ErrInvalidCredentials,
+			code:   marvel.CodeInvalidHash,
+			eMsg:   "marvel: InvalidHash That hash, timestamp and key combination is invalid.",
+		},
+		{
+			desc:   "throttled",
+			status: 429,
+			body:   `{"code": "RequestThrottled", "message": "You have exceeded your rate limit."}`,
+			kind:   marvel.ErrRateLimited,
+			code:   marvel.CodeRequestThrottled,
+			eMsg:   "marvel: RequestThrottled You have exceeded your rate limit.",
+		},
+		{
+			desc:   "missing parameter",
+			status: 409,
+			body:   `{"code": "MissingParameter", "message": "You must provide a hash."}`,
+			kind:   marvel.ErrInvalidParameter,
+			code:   marvel.CodeMissingParameter,
+			eMsg:   "marvel: MissingParameter You must provide a hash.",
+		},
+		{
+			desc:   "usage error described by status",
+			status: 409,
+			body:   `{"code": 409, "status": "You may not request more than 100 items."}`,
+			kind:   marvel.ErrInvalidParameter,
+			code:   "409",
+			eMsg:   "marvel: 409 You may not request more than 100 items.",
+		},
+		{
+			desc:   "not found",
+			status: 404,
+			body:   `{"code": 404, "status": "We couldn't find that character"}`,
+			kind:   marvel.ErrNotFound,
+			code:   "404",
+			eMsg:   "marvel: 404 We couldn't find that character",
+		},
+		{
+			desc:   "HTML gateway page",
+			status: 502,
+			body:   "<html><body>Bad Gateway</body></html>\n",
+			code:   "502",
+			eMsg:   "marvel: 502 Bad Gateway",
+			eBody:  "<html><body>Bad Gateway</body></html>",
+		},
+		{
+			desc:   "empty body",
+			status: 404,
+			kind:   marvel.ErrNotFound,
+			code:   "404",
+			eMsg:   "marvel: 404 Not Found",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			c := newServerClient(t, &mockAuth{},
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(tC.status)
+					fmt.Fprint(w, tC.body)
+				}))
+
+			_, _, err := c.Characters.AllWrapped(&marvel.CharacterParams{Name: "Thor"})
+
+			var apiErr *marvel.APIError
+			if !assert.True(t, errors.As(err, &apiErr), "Expected an APIError, got %v", err) {
+				return
+			}
+			assert.EqualError(t, err, tC.eMsg)
+			assert.Equal(t, tC.code, apiErr.Code)
+			assert.Equal(t, tC.status, apiErr.StatusCode)
+			assert.Equal(t, "https://gateway.marvel.com/v1/public/characters?name=Thor", apiErr.URL)
+			assert.Equal(t, tC.eBody, apiErr.Body)
+			for _, kind := range kinds {
+				assert.Equal(t, kind == tC.kind, errors.Is(err, kind), "errors.Is(err, %v)", kind)
+			}
+		})
+	}
+}
+
+// getFuncs call Get on each of the Client's services.
+var getFuncs = map[marvel.Resource]func(c *marvel.Client, id int) (interface{}, error){
+	marvel.CharactersResource: func(c *marvel.Client, id int) (interface{}, error) { return c.Characters.Get(id) },
+	marvel.ComicsResource:     func(c *marvel.Client, id int) (interface{}, error) { return c.Comics.Get(id) },
+	marvel.CreatorsResource:   func(c *marvel.Client, id int) (interface{}, error) { return c.Creators.Get(id) },
+	marvel.EventsResource:     func(c *marvel.Client, id int) (interface{}, error) { return c.Events.Get(id) },
+	marvel.SeriesResource:     func(c *marvel.Client, id int) (interface{}, error) { return c.Series.Get(id) },
+	marvel.StoriesResource:    func(c *marvel.Client, id int) (interface{}, error) { return c.Stories.Get(id) },
+}
+
+// allFuncs call All on each of the Client's services.
+var allFuncs = map[marvel.Resource]func(c *marvel.Client) (interface{}, error){
+	marvel.CharactersResource: func(c *marvel.Client) (interface{}, error) { return c.Characters.All(nil) },
+	marvel.ComicsResource:     func(c *marvel.Client) (interface{}, error) { return c.Comics.All(nil) },
+	marvel.CreatorsResource:   func(c *marvel.Client) (interface{}, error) { return c.Creators.All(nil) },
+	marvel.EventsResource:     func(c *marvel.Client) (interface{}, error) { return c.Events.All(nil) },
+	marvel.SeriesResource:     func(c *marvel.Client) (interface{}, error) { return c.Series.All(nil) },
+	marvel.StoriesResource:    func(c *marvel.Client) (interface{}, error) { return c.Stories.All(nil) },
+}
+
+func TestGetNotFound(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		status   int
+		body     string
+		notFound bool
+		wrapped  bool
+	}{
+		{
+			desc:     "empty results",
+			status:   200,
+			body:     `{"code": 200, "data": {"total": 0, "count": 0, "results": []}}`,
+			notFound: true,
+		},
+		{
+			desc:     "missing results",
+			status:   200,
+			body:     `{"code": 200, "data": {}}`,
+			notFound: true,
+		},
+		{
+			desc:     "404 response",
+			status:   404,
+			body:     `{"code": 404, "status": "We couldn't find that entity"}`,
+			notFound: true,
+			wrapped:  true,
+		},
+		{
+			desc:   "malformed payload",
+			status: 200,
+			body:   `{"code": 200, "data": {"results": [{"id": "one"`,
+		},
+	}
+	for _, tC := range testCases {
+		for resource, get := range getFuncs {
+			t.Run(fmt.Sprintf("%s %s", resource, tC.desc), func(t *testing.T) {
+				c := newServerClient(t, &mockAuth{},
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						w.WriteHeader(tC.status)
+						fmt.Fprint(w, tC.body)
+					}))
+
+				entity, err := get(c, 42)
+				assert.Nil(t, entity)
+				assert.Error(t, err)
+				assert.Equal(t, tC.notFound, errors.Is(err, marvel.ErrNotFound), "errors.Is(err, ErrNotFound)")
+
+				var nfe *marvel.NotFoundError
+				if tC.notFound && assert.True(t, errors.As(err, &nfe)) {
+					assert.Equal(t, resource, nfe.Resource)
+					assert.Equal(t, 42, nfe.ID)
+					assert.EqualError(t, err, fmt.Sprintf("marvel: %s/42 not found", resource))
+					var apiErr *marvel.APIError
+					assert.Equal(t, tC.wrapped, errors.As(err, &apiErr), "errors.As(err, *APIError)")
+				}
+			})
+		}
+	}
+}
+
+func TestAllErrorResults(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		status int
+		body   string
+	}{
+		{
+			desc:   "error response",
+			status: 409,
+			body:   `{"code": 409, "status": "Invalid or unrecognized ordering parameter."}`,
+		},
+		{
+			desc:   "malformed payload",
+			status: 200,
+			body:   `{"code": 200, "data": {"results": [{"id": 1}, {"id": "two"}]}}`,
+		},
+	}
+	for _, tC := range testCases {
+		for resource, all := range allFuncs {
+			t.Run(fmt.Sprintf("%s %s", resource, tC.desc), func(t *testing.T) {
+				c := newServerClient(t, &mockAuth{},
+					http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+						w.WriteHeader(tC.status)
+						fmt.Fprint(w, tC.body)
+					}))
+
+				results, err := all(c)
+				assert.Error(t, err)
+				assert.Nil(t, results, "Results returned alongside an error")
+			})
+		}
+	}
+}
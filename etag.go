@@ -0,0 +1,148 @@
+package marvel
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// revalidatedHeader marks responses whose body was served from the ETag cache
+// after the API replied 304 Not Modified.
+const revalidatedHeader = "X-Marvel-Revalidated"
+
+// Revalidated reports whether the response's body came from a cached entry which
+// the API confirmed, by replying 304 Not Modified, is still current.
+func Revalidated(resp *http.Response) bool {
+	return resp != nil && resp.Header.Get(revalidatedHeader) != ""
+}
+
+// DefaultETagCapacity is the number of responses a Client remembers for
+// revalidation, unless changed with ETagCapacity.
+const DefaultETagCapacity = 1000
+
+// etagEntry is a response remembered for revalidation.
+type etagEntry struct {
+	etag   string
+	header http.Header
+	body   []byte
+}
+
+// response returns a new 200 OK response holding the entry's body, marked as
+// revalidated.
+func (ee *etagEntry) response(req *http.Request) *http.Response {
+	header := ee.header.Clone()
+	header.Set(revalidatedHeader, "true")
+	header.Set("Content-Length", strconv.Itoa(len(ee.body)))
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(ee.body)),
+		ContentLength: int64(len(ee.body)),
+		Request:       req,
+	}
+}
+
+// etagTransport is an http.RoundTripper which remembers the ETag and body of
+// each successful GET request, sending If-None-Match when the same URL is
+// requested again. A 304 Not Modified reply is answered with the remembered
+// body. Once capacity responses are remembered, the least recently used is
+// forgotten.
+type etagTransport struct {
+	mu       sync.Mutex
+	enabled  bool
+	capacity int
+	entries  *lru
+	base     http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (et *etagTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return et.base.RoundTrip(req)
+	}
+	key := canonicalURL(req.URL)
+	et.mu.Lock()
+	enabled := et.enabled
+	var entry *etagEntry
+	if v, ok := et.entries.get(key); ok {
+		entry = v.(*etagEntry)
+	}
+	et.mu.Unlock()
+	if !enabled {
+		return et.base.RoundTrip(req)
+	}
+
+	if entry != nil {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", entry.etag)
+	}
+	resp, err := et.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && entry != nil:
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+		return entry.response(req), nil
+	case resp.StatusCode == http.StatusOK:
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+		if etag := responseETag(resp.Header, body); etag != "" {
+			et.mu.Lock()
+			et.entries.add(key, &etagEntry{
+				etag:   etag,
+				header: resp.Header.Clone(),
+				body:   body,
+			})
+			et.mu.Unlock()
+		}
+	}
+	return resp, nil
+}
+
+// enable turns conditional requests on or off, forgetting all remembered
+// responses when turned off.
+func (et *etagTransport) enable(enabled bool) {
+	et.mu.Lock()
+	defer et.mu.Unlock()
+	et.enabled = enabled
+	if !enabled || et.entries == nil {
+		et.entries = newLRU(et.capacity)
+	}
+}
+
+// setCapacity changes the number of responses remembered, forgetting all those
+// remembered so far.
+func (et *etagTransport) setCapacity(capacity int) {
+	et.mu.Lock()
+	defer et.mu.Unlock()
+	et.capacity = capacity
+	et.entries = newLRU(capacity)
+}
+
+// responseETag returns the ETag header or, failing that, the etag given in the
+// body's DataWrapper.
+func responseETag(header http.Header, body []byte) string {
+	if etag := header.Get("ETag"); etag != "" {
+		return etag
+	}
+	wrap := &DataWrapper{}
+	if json.Unmarshal(body, wrap) != nil {
+		return ""
+	}
+	return wrap.ETag
+}
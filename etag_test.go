@@ -0,0 +1,101 @@
+package marvel_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/dustinrc/marvel"
+	"github.com/stretchr/testify/assert"
+)
+
+// etagHandler serves a single character whose name, and with it the etag,
+// may be changed between requests. The If-None-Match header of every request
+// is recorded.
+type etagHandler struct {
+	name        string
+	inHeader    bool
+	ifNoneMatch []string
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (eh *etagHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	etag := "etag-" + eh.name
+	eh.ifNoneMatch = append(eh.ifNoneMatch, r.Header.Get("If-None-Match"))
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	if eh.inHeader {
+		w.Header().Set("ETag", etag)
+		etag = ""
+	}
+	fmt.Fprintf(w, `{"code": 200, "etag": %q, "data": {"results": [{"id": 1, "name": %q}]}}`, etag, eh.name)
+}
+
+func TestClientConditionalRequests(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		inHeader bool
+	}{
+		{desc: "etag given in body", inHeader: false},
+		{desc: "etag given in header", inHeader: true},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			eh := &etagHandler{name: "Thor", inHeader: tC.inHeader}
+			c := newServerClient(t, &mockAuth{}, eh)
+			c.ConditionalRequests(true)
+
+			wrap, resp, err := c.Characters.GetWrapped(1)
+			assert.NoError(t, err)
+			assert.False(t, marvel.Revalidated(resp))
+			assert.Equal(t, "Thor", wrap.Data.Results[0].Name)
+
+			wrap, resp, err = c.Characters.GetWrapped(1)
+			assert.NoError(t, err)
+			assert.True(t, marvel.Revalidated(resp), "Response was not revalidated")
+			assert.Equal(t, 200, resp.StatusCode)
+			assert.Equal(t, "Thor", wrap.Data.Results[0].Name)
+
+			eh.name = "Loki"
+			wrap, resp, err = c.Characters.GetWrapped(1)
+			assert.NoError(t, err)
+			assert.False(t, marvel.Revalidated(resp))
+			assert.Equal(t, "Loki", wrap.Data.Results[0].Name)
+
+			assert.Equal(t, []string{"", "etag-Thor", "etag-Thor"}, eh.ifNoneMatch)
+		})
+	}
+}
+
+func TestClientConditionalRequestsOff(t *testing.T) {
+	eh := &etagHandler{name: "Thor"}
+	c := newServerClient(t, &mockAuth{}, eh)
+
+	for i := 0; i < 2; i++ {
+		_, resp, err := c.Characters.GetWrapped(1)
+		assert.NoError(t, err)
+		assert.False(t, marvel.Revalidated(resp))
+	}
+	c.ConditionalRequests(true)
+	c.Characters.GetWrapped(1)
+	c.ConditionalRequests(false)
+	c.Characters.GetWrapped(1)
+
+	assert.Equal(t, []string{"", "", "", ""}, eh.ifNoneMatch)
+}
+
+func TestClientETagCapacity(t *testing.T) {
+	eh := &etagHandler{name: "Thor"}
+	c := newServerClient(t, &mockAuth{}, eh)
+	c.ConditionalRequests(true)
+	c.ETagCapacity(2)
+
+	for _, id := range []int{1, 2, 1, 3, 1, 2} {
+		_, err := c.Characters.Get(id)
+		assert.NoError(t, err)
+	}
+	// Requesting 3 forgets 2, the least recently used; 1 stays remembered.
+	assert.Equal(t, []string{"", "", "etag-Thor", "", "etag-Thor", ""}, eh.ifNoneMatch)
+}
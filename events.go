@@ -1,6 +1,7 @@
 package marvel
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -23,107 +24,308 @@ func NewEventService(sling *sling.Sling) *EventService {
 // AllWrapped returns all events that match the query parameters. The event
 // slice will be encapsulated by EventDataContainer and EventDataWrapper.
 func (evs *EventService) AllWrapped(params *EventParams) (*EventDataWrapper, *http.Response, error) {
+	return evs.AllWrappedContext(context.Background(), params)
+}
+
+// AllWrappedContext is like AllWrapped, but the request is sent using ctx.
+func (evs *EventService) AllWrappedContext(ctx context.Context, params *EventParams) (*EventDataWrapper, *http.Response, error) {
 	wrap := &EventDataWrapper{}
-	resp, err := receiveWrapped(evs.sling, "../events", wrap, params)
+	resp, err := receiveWrapped(ctx, evs.sling, "../events", wrap, params)
 	return wrap, resp, err
 }
 
 // All returns all events that match the query parameters.
 func (evs *EventService) All(params *EventParams) ([]Event, error) {
-	wrap, _, err := evs.AllWrapped(params)
-	return wrap.Data.Results, err
+	return evs.AllContext(context.Background(), params)
+}
+
+// AllContext is like All, but the request is sent using ctx.
+func (evs *EventService) AllContext(ctx context.Context, params *EventParams) ([]Event, error) {
+	wrap, _, err := evs.AllWrappedContext(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
 }
 
 // GetWrapped returns the event associated with the given ID. The event
 // details will be encapsulated by EventDataContainer and EventDataWrapper.
 func (evs *EventService) GetWrapped(eventID int) (*EventDataWrapper, *http.Response, error) {
+	return evs.GetWrappedContext(context.Background(), eventID)
+}
+
+// GetWrappedContext is like GetWrapped, but the request is sent using ctx.
+func (evs *EventService) GetWrappedContext(ctx context.Context, eventID int) (*EventDataWrapper, *http.Response, error) {
 	wrap := &EventDataWrapper{}
-	resp, err := receiveWrapped(evs.sling, fmt.Sprintf("%d", eventID), wrap, nil)
+	resp, err := receiveWrapped(ctx, evs.sling, fmt.Sprintf("%d", eventID), wrap, nil)
 	return wrap, resp, err
 }
 
-// Get returns the event associated with the given ID.
+// Get returns the event associated with the given ID. A NotFoundError is
+// returned if there is no such event.
 func (evs *EventService) Get(eventID int) (*Event, error) {
-	wrap, _, err := evs.GetWrapped(eventID)
-	if err != nil {
+	return evs.GetContext(context.Background(), eventID)
+}
+
+// GetContext is like Get, but the request is sent using ctx.
+func (evs *EventService) GetContext(ctx context.Context, eventID int) (*Event, error) {
+	wrap, _, err := evs.GetWrappedContext(ctx, eventID)
+	if err = getError(EventsResource, eventID, err, len(wrap.Data.Results)); err != nil {
 		return nil, err
 	}
 	return &wrap.Data.Results[0], nil
 }
 
+// GetMany returns the events associated with the given IDs, keyed by ID. The
+// events are requested concurrently, at most workers at a time, and any errors
+// are returned keyed by the ID that caused them. A workers value less than 1 is
+// treated as 1.
+func (evs *EventService) GetMany(eventIDs []int, workers int) (map[int]*Event, map[int]error) {
+	return evs.GetManyContext(context.Background(), eventIDs, workers)
+}
+
+// GetManyContext is like GetMany, but the requests are sent using ctx.
+func (evs *EventService) GetManyContext(ctx context.Context, eventIDs []int, workers int) (map[int]*Event, map[int]error) {
+	values, errs := getMany(ctx, eventIDs, workers, func(ctx context.Context, id int) (interface{}, error) {
+		return evs.GetContext(ctx, id)
+	})
+	events := make(map[int]*Event, len(values))
+	for id, v := range values {
+		events[id] = v.(*Event)
+	}
+	return events, errs
+}
+
 // CharactersWrapped returns all characters involving the given event and match the
 // query parameters. The character slice will be encapsulated by CharacterDataContainer
 // and CharacterDataWrapper.
 func (evs *EventService) CharactersWrapped(eventID int, params *CharacterParams) (*CharacterDataWrapper, *http.Response, error) {
+	return evs.CharactersWrappedContext(context.Background(), eventID, params)
+}
+
+// CharactersWrappedContext is like CharactersWrapped, but the request is sent using ctx.
+func (evs *EventService) CharactersWrappedContext(ctx context.Context, eventID int, params *CharacterParams) (*CharacterDataWrapper, *http.Response, error) {
 	wrap := &CharacterDataWrapper{}
-	resp, err := receiveWrapped(evs.sling, fmt.Sprintf("%d/characters", eventID), wrap, params)
+	resp, err := receiveWrapped(ctx, evs.sling, fmt.Sprintf("%d/characters", eventID), wrap, params)
 	return wrap, resp, err
 }
 
 // Characters returns all characters involving the given event and match the query parameters.
 func (evs *EventService) Characters(eventID int, params *CharacterParams) ([]Character, error) {
-	wrap, _, err := evs.CharactersWrapped(eventID, params)
-	return wrap.Data.Results, err
+	return evs.CharactersContext(context.Background(), eventID, params)
+}
+
+// CharactersContext is like Characters, but the request is sent using ctx.
+func (evs *EventService) CharactersContext(ctx context.Context, eventID int, params *CharacterParams) ([]Character, error) {
+	wrap, _, err := evs.CharactersWrappedContext(ctx, eventID, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
 }
 
 // ComicsWrapped returns all comics involving the given event and match the
 // query parameters. The comic slice will be encapsulated by ComicDataContainer
 // and ComicDataWrapper.
 func (evs *EventService) ComicsWrapped(eventID int, params *ComicParams) (*ComicDataWrapper, *http.Response, error) {
+	return evs.ComicsWrappedContext(context.Background(), eventID, params)
+}
+
+// ComicsWrappedContext is like ComicsWrapped, but the request is sent using ctx.
+func (evs *EventService) ComicsWrappedContext(ctx context.Context, eventID int, params *ComicParams) (*ComicDataWrapper, *http.Response, error) {
 	wrap := &ComicDataWrapper{}
-	resp, err := receiveWrapped(evs.sling, fmt.Sprintf("%d/comics", eventID), wrap, params)
+	resp, err := receiveWrapped(ctx, evs.sling, fmt.Sprintf("%d/comics", eventID), wrap, params)
 	return wrap, resp, err
 }
 
 // Comics returns all comics involving the given event and match the query parameters.
 func (evs *EventService) Comics(eventID int, params *ComicParams) ([]Comic, error) {
-	wrap, _, err := evs.ComicsWrapped(eventID, params)
-	return wrap.Data.Results, err
+	return evs.ComicsContext(context.Background(), eventID, params)
+}
+
+// ComicsContext is like Comics, but the request is sent using ctx.
+func (evs *EventService) ComicsContext(ctx context.Context, eventID int, params *ComicParams) ([]Comic, error) {
+	wrap, _, err := evs.ComicsWrappedContext(ctx, eventID, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
 }
 
 // CreatorsWrapped returns all creators involving the given event and match the
 // query parameters. The creator slice will be encapsulated by CreatorDataContainer
 // and CreatorDataWrapper.
 func (evs *EventService) CreatorsWrapped(eventID int, params *CreatorParams) (*CreatorDataWrapper, *http.Response, error) {
+	return evs.CreatorsWrappedContext(context.Background(), eventID, params)
+}
+
+// CreatorsWrappedContext is like CreatorsWrapped, but the request is sent using ctx.
+func (evs *EventService) CreatorsWrappedContext(ctx context.Context, eventID int, params *CreatorParams) (*CreatorDataWrapper, *http.Response, error) {
 	wrap := &CreatorDataWrapper{}
-	resp, err := receiveWrapped(evs.sling, fmt.Sprintf("%d/creators", eventID), wrap, params)
+	resp, err := receiveWrapped(ctx, evs.sling, fmt.Sprintf("%d/creators", eventID), wrap, params)
 	return wrap, resp, err
 }
 
 // Creators returns all creators involving the given event and match the query parameters.
 func (evs *EventService) Creators(eventID int, params *CreatorParams) ([]Creator, error) {
-	wrap, _, err := evs.CreatorsWrapped(eventID, params)
-	return wrap.Data.Results, err
+	return evs.CreatorsContext(context.Background(), eventID, params)
+}
+
+// CreatorsContext is like Creators, but the request is sent using ctx.
+func (evs *EventService) CreatorsContext(ctx context.Context, eventID int, params *CreatorParams) ([]Creator, error) {
+	wrap, _, err := evs.CreatorsWrappedContext(ctx, eventID, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
 }
 
 // SeriesWrapped returns all series involving the given event and match the
 // query parameters. The series slice will be encapsulated by SeriesDataContainer
 // and SeriesDataWrapper.
 func (evs *EventService) SeriesWrapped(eventID int, params *SeriesParams) (*SeriesDataWrapper, *http.Response, error) {
+	return evs.SeriesWrappedContext(context.Background(), eventID, params)
+}
+
+// SeriesWrappedContext is like SeriesWrapped, but the request is sent using ctx.
+func (evs *EventService) SeriesWrappedContext(ctx context.Context, eventID int, params *SeriesParams) (*SeriesDataWrapper, *http.Response, error) {
 	wrap := &SeriesDataWrapper{}
-	resp, err := receiveWrapped(evs.sling, fmt.Sprintf("%d/series", eventID), wrap, params)
+	resp, err := receiveWrapped(ctx, evs.sling, fmt.Sprintf("%d/series", eventID), wrap, params)
 	return wrap, resp, err
 }
 
 // Series returns all series involving the given event and match the query parameters.
 func (evs *EventService) Series(eventID int, params *SeriesParams) ([]Series, error) {
-	wrap, _, err := evs.SeriesWrapped(eventID, params)
-	return wrap.Data.Results, err
+	return evs.SeriesContext(context.Background(), eventID, params)
+}
+
+// SeriesContext is like Series, but the request is sent using ctx.
+func (evs *EventService) SeriesContext(ctx context.Context, eventID int, params *SeriesParams) ([]Series, error) {
+	wrap, _, err := evs.SeriesWrappedContext(ctx, eventID, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
 }
 
 // StoriesWrapped returns all stories involving the given event and match the
 // query parameters. The story slice will be encapsulated by StoryDataContainer
 // and StoryDataWrapper.
 func (evs *EventService) StoriesWrapped(eventID int, params *StoryParams) (*StoryDataWrapper, *http.Response, error) {
+	return evs.StoriesWrappedContext(context.Background(), eventID, params)
+}
+
+// StoriesWrappedContext is like StoriesWrapped, but the request is sent using ctx.
+func (evs *EventService) StoriesWrappedContext(ctx context.Context, eventID int, params *StoryParams) (*StoryDataWrapper, *http.Response, error) {
 	wrap := &StoryDataWrapper{}
-	resp, err := receiveWrapped(evs.sling, fmt.Sprintf("%d/stories", eventID), wrap, params)
+	resp, err := receiveWrapped(ctx, evs.sling, fmt.Sprintf("%d/stories", eventID), wrap, params)
 	return wrap, resp, err
 }
 
 // Stories returns all stories involving the given event and match the query parameters.
 func (evs *EventService) Stories(eventID int, params *StoryParams) ([]Story, error) {
-	wrap, _, err := evs.StoriesWrapped(eventID, params)
-	return wrap.Data.Results, err
+	return evs.StoriesContext(context.Background(), eventID, params)
+}
+
+// StoriesContext is like Stories, but the request is sent using ctx.
+func (evs *EventService) StoriesContext(ctx context.Context, eventID int, params *StoryParams) ([]Story, error) {
+	wrap, _, err := evs.StoriesWrappedContext(ctx, eventID, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
+}
+
+// AllIter returns an EventIterator over all events that match the query
+// parameters, regardless of how many pages of results there are.
+func (evs *EventService) AllIter(params *EventParams) *EventIterator {
+	return evs.AllIterContext(context.Background(), params)
+}
+
+// AllIterContext is like AllIter, but the requests are sent using ctx.
+func (evs *EventService) AllIterContext(ctx context.Context, params *EventParams) *EventIterator {
+	return NewEventIterator(ctx, params, evs.AllWrappedContext)
+}
+
+// Walk calls fn for all events that match the query parameters, regardless
+// of how many pages of results there are.
+func (evs *EventService) Walk(params *EventParams, fn func(Event) error) error {
+	return evs.WalkContext(context.Background(), params, fn)
+}
+
+// WalkContext is like Walk, but the requests are sent using ctx.
+func (evs *EventService) WalkContext(ctx context.Context, params *EventParams, fn func(Event) error) error {
+	return evs.AllIterContext(ctx, params).Walk(fn)
+}
+
+// CharactersIter returns a CharacterIterator over all characters involving the given
+// event and match the query parameters, regardless of how many pages of
+// results there are.
+func (evs *EventService) CharactersIter(eventID int, params *CharacterParams) *CharacterIterator {
+	return evs.CharactersIterContext(context.Background(), eventID, params)
+}
+
+// CharactersIterContext is like CharactersIter, but the requests are sent using ctx.
+func (evs *EventService) CharactersIterContext(ctx context.Context, eventID int, params *CharacterParams) *CharacterIterator {
+	return NewCharacterIterator(ctx, params, func(ctx context.Context, params *CharacterParams) (*CharacterDataWrapper, *http.Response, error) {
+		return evs.CharactersWrappedContext(ctx, eventID, params)
+	})
+}
+
+// ComicsIter returns a ComicIterator over all comics involving the given
+// event and match the query parameters, regardless of how many pages of
+// results there are.
+func (evs *EventService) ComicsIter(eventID int, params *ComicParams) *ComicIterator {
+	return evs.ComicsIterContext(context.Background(), eventID, params)
+}
+
+// ComicsIterContext is like ComicsIter, but the requests are sent using ctx.
+func (evs *EventService) ComicsIterContext(ctx context.Context, eventID int, params *ComicParams) *ComicIterator {
+	return NewComicIterator(ctx, params, func(ctx context.Context, params *ComicParams) (*ComicDataWrapper, *http.Response, error) {
+		return evs.ComicsWrappedContext(ctx, eventID, params)
+	})
+}
+
+// CreatorsIter returns a CreatorIterator over all creators involving the given
+// event and match the query parameters, regardless of how many pages of
+// results there are.
+func (evs *EventService) CreatorsIter(eventID int, params *CreatorParams) *CreatorIterator {
+	return evs.CreatorsIterContext(context.Background(), eventID, params)
+}
+
+// CreatorsIterContext is like CreatorsIter, but the requests are sent using ctx.
+func (evs *EventService) CreatorsIterContext(ctx context.Context, eventID int, params *CreatorParams) *CreatorIterator {
+	return NewCreatorIterator(ctx, params, func(ctx context.Context, params *CreatorParams) (*CreatorDataWrapper, *http.Response, error) {
+		return evs.CreatorsWrappedContext(ctx, eventID, params)
+	})
+}
+
+// SeriesIter returns a SeriesIterator over all series involving the given
+// event and match the query parameters, regardless of how many pages of
+// results there are.
+func (evs *EventService) SeriesIter(eventID int, params *SeriesParams) *SeriesIterator {
+	return evs.SeriesIterContext(context.Background(), eventID, params)
+}
+
+// SeriesIterContext is like SeriesIter, but the requests are sent using ctx.
+func (evs *EventService) SeriesIterContext(ctx context.Context, eventID int, params *SeriesParams) *SeriesIterator {
+	return NewSeriesIterator(ctx, params, func(ctx context.Context, params *SeriesParams) (*SeriesDataWrapper, *http.Response, error) {
+		return evs.SeriesWrappedContext(ctx, eventID, params)
+	})
+}
+
+// StoriesIter returns a StoryIterator over all stories involving the given
+// event and match the query parameters, regardless of how many pages of
+// results there are.
+func (evs *EventService) StoriesIter(eventID int, params *StoryParams) *StoryIterator {
+	return evs.StoriesIterContext(context.Background(), eventID, params)
+}
+
+// StoriesIterContext is like StoriesIter, but the requests are sent using ctx.
+func (evs *EventService) StoriesIterContext(ctx context.Context, eventID int, params *StoryParams) *StoryIterator {
+	return NewStoryIterator(ctx, params, func(ctx context.Context, params *StoryParams) (*StoryDataWrapper, *http.Response, error) {
+		return evs.StoriesWrappedContext(ctx, eventID, params)
+	})
 }
 
 // EventDataWrapper provides event wrapper information returned by the API.
@@ -161,17 +363,56 @@ type Event struct {
 // EventParams are optional parameters to narrow the event results returned
 // by the API, as well as specify the number and order.
 type EventParams struct {
-	Name           string    `url:"name,omitempty"`
-	NameStartsWith string    `url:"nameStartsWith,omitempty"`
-	ModifiedSince  time.Time `url:"modifiedSince,omitempty"`
-	Creators       []int     `url:"creators,omitempty"`
-	Characters     []int     `url:"characters,omitempty"`
-	Series         []int     `url:"series,omitempty"`
-	Comics         []int     `url:"comics,omitempty"`
-	Stories        []int     `url:"stories,omitempty"`
-	OrderBy        string    `url:"orderBy,omitempty"`
-	Limit          int       `url:"limit,omitempty"`
-	Offset         int       `url:"offset,omitempty"`
+	Name           string       `url:"name,omitempty"`
+	NameStartsWith string       `url:"nameStartsWith,omitempty"`
+	ModifiedSince  time.Time    `url:"modifiedSince,omitempty" layout:"2006-01-02T15:04:05-0700"`
+	Creators       IDList       `url:"creators,omitempty"`
+	Characters     IDList       `url:"characters,omitempty"`
+	Series         IDList       `url:"series,omitempty"`
+	Comics         IDList       `url:"comics,omitempty"`
+	Stories        IDList       `url:"stories,omitempty"`
+	OrderBy        EventOrderBy `url:"orderBy,omitempty"`
+	Limit          int          `url:"limit,omitempty"`
+	Offset         int          `url:"offset,omitempty"`
+}
+
+// EventOrderBy is the order of a list of events. Orders may be joined with
+// commas, in decreasing priority, e.g., "name,-modified".
+type EventOrderBy string
+
+// The orders in which events can be listed. The Desc variants sort in
+// descending order.
+const (
+	EventOrderByName          EventOrderBy = "name"
+	EventOrderByNameDesc      EventOrderBy = "-name"
+	EventOrderByStartDate     EventOrderBy = "startDate"
+	EventOrderByStartDateDesc EventOrderBy = "-startDate"
+	EventOrderByModified      EventOrderBy = "modified"
+	EventOrderByModifiedDesc  EventOrderBy = "-modified"
+)
+
+// valid reports whether the API accepts the order.
+func (o EventOrderBy) valid() bool {
+	return validOrder(string(o), "name", "startDate", "modified")
+}
+
+// Validate returns a *ValidationError listing each of the parameters the API
+// would reject. It is called before each request is sent with the parameters.
+func (p *EventParams) Validate() error {
+	if p == nil {
+		return nil
+	}
+	v := newValidation("EventParams")
+	v.text("Name", p.Name)
+	v.text("NameStartsWith", p.NameStartsWith)
+	v.ids("Creators", p.Creators)
+	v.ids("Characters", p.Characters)
+	v.ids("Series", p.Series)
+	v.ids("Comics", p.Comics)
+	v.ids("Stories", p.Stories)
+	v.enum(p.OrderBy.valid(), "OrderBy", string(p.OrderBy))
+	v.page(p.Limit, p.Offset)
+	return v.err()
 }
 
 // EventList provides event related to the parent entity.
@@ -0,0 +1,216 @@
+package marvel
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// incomplete reports whether the list holds fewer items than are available.
+// Lists embedded in entities are capped at 20 items.
+func (l List) incomplete() bool {
+	return l.Returned < l.Available
+}
+
+// collectionPath returns the list's CollectionURI as a path relative to the
+// Client's base URL. The URI's host and any path before the API version are
+// ignored, as the API always gives its own.
+func (c *Client) collectionPath(l List) (string, error) {
+	versionPath := "/" + c.version + "/public/"
+	u, err := url.Parse(l.CollectionURI)
+	if err != nil || !strings.Contains(u.Path, versionPath) || strings.HasSuffix(u.Path, versionPath) {
+		return "", fmt.Errorf("marvel: invalid collection URI %q", l.CollectionURI)
+	}
+	return u.Path[strings.Index(u.Path, versionPath)+len(versionPath):], nil
+}
+
+// ExpandCharacters returns all characters in the list. If the list was
+// truncated, every character is fetched from its CollectionURI.
+func (c *Client) ExpandCharacters(list CharacterList) ([]CharacterSummary, error) {
+	return c.ExpandCharactersContext(context.Background(), list)
+}
+
+// ExpandCharactersContext is like ExpandCharacters, but the requests are sent using ctx.
+func (c *Client) ExpandCharactersContext(ctx context.Context, list CharacterList) ([]CharacterSummary, error) {
+	if !list.incomplete() {
+		return list.Items, nil
+	}
+	path, err := c.collectionPath(list.List)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]CharacterSummary, 0, list.Available)
+	err = NewCharacterIterator(ctx, nil, func(ctx context.Context, params *CharacterParams) (*CharacterDataWrapper, *http.Response, error) {
+		wrap := &CharacterDataWrapper{}
+		resp, err := receiveWrapped(ctx, c.sling, path, wrap, params)
+		return wrap, resp, err
+	}).Walk(func(ch Character) error {
+		items = append(items, CharacterSummary{Summary: Summary{ResourceURI: ch.ResourceURI, Name: ch.Name}})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// ExpandComics returns all comics in the list. If the list was truncated,
+// every comic is fetched from its CollectionURI.
+func (c *Client) ExpandComics(list ComicList) ([]ComicSummary, error) {
+	return c.ExpandComicsContext(context.Background(), list)
+}
+
+// ExpandComicsContext is like ExpandComics, but the requests are sent using ctx.
+func (c *Client) ExpandComicsContext(ctx context.Context, list ComicList) ([]ComicSummary, error) {
+	if !list.incomplete() {
+		return list.Items, nil
+	}
+	path, err := c.collectionPath(list.List)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]ComicSummary, 0, list.Available)
+	err = NewComicIterator(ctx, nil, func(ctx context.Context, params *ComicParams) (*ComicDataWrapper, *http.Response, error) {
+		wrap := &ComicDataWrapper{}
+		resp, err := receiveWrapped(ctx, c.sling, path, wrap, params)
+		return wrap, resp, err
+	}).Walk(func(co Comic) error {
+		items = append(items, ComicSummary{Summary: Summary{ResourceURI: co.ResourceURI, Name: co.Title}})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// ExpandCreators returns all creators in the list. If the list was
+// truncated, every creator is fetched from its CollectionURI, and keeps the
+// Role it had among the list's Items. The roles of creators fetched only from
+// the collection are unknown, and left empty.
+func (c *Client) ExpandCreators(list CreatorList) ([]CreatorSummary, error) {
+	return c.ExpandCreatorsContext(context.Background(), list)
+}
+
+// ExpandCreatorsContext is like ExpandCreators, but the requests are sent using ctx.
+func (c *Client) ExpandCreatorsContext(ctx context.Context, list CreatorList) ([]CreatorSummary, error) {
+	if !list.incomplete() {
+		return list.Items, nil
+	}
+	path, err := c.collectionPath(list.List)
+	if err != nil {
+		return nil, err
+	}
+	roles := make(map[string]string, len(list.Items))
+	for _, item := range list.Items {
+		roles[item.ResourceURI] = item.Role
+	}
+	items := make([]CreatorSummary, 0, list.Available)
+	err = NewCreatorIterator(ctx, nil, func(ctx context.Context, params *CreatorParams) (*CreatorDataWrapper, *http.Response, error) {
+		wrap := &CreatorDataWrapper{}
+		resp, err := receiveWrapped(ctx, c.sling, path, wrap, params)
+		return wrap, resp, err
+	}).Walk(func(ctr Creator) error {
+		items = append(items, CreatorSummary{
+			Summary: Summary{ResourceURI: ctr.ResourceURI, Name: ctr.FullName},
+			Role:    roles[ctr.ResourceURI],
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// ExpandEvents returns all events in the list. If the list was truncated,
+// every event is fetched from its CollectionURI.
+func (c *Client) ExpandEvents(list EventList) ([]EventSummary, error) {
+	return c.ExpandEventsContext(context.Background(), list)
+}
+
+// ExpandEventsContext is like ExpandEvents, but the requests are sent using ctx.
+func (c *Client) ExpandEventsContext(ctx context.Context, list EventList) ([]EventSummary, error) {
+	if !list.incomplete() {
+		return list.Items, nil
+	}
+	path, err := c.collectionPath(list.List)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]EventSummary, 0, list.Available)
+	err = NewEventIterator(ctx, nil, func(ctx context.Context, params *EventParams) (*EventDataWrapper, *http.Response, error) {
+		wrap := &EventDataWrapper{}
+		resp, err := receiveWrapped(ctx, c.sling, path, wrap, params)
+		return wrap, resp, err
+	}).Walk(func(ev Event) error {
+		items = append(items, EventSummary{Summary: Summary{ResourceURI: ev.ResourceURI, Name: ev.Title}})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// ExpandSeries returns all series in the list. If the list was truncated,
+// every series is fetched from its CollectionURI.
+func (c *Client) ExpandSeries(list SeriesList) ([]SeriesSummary, error) {
+	return c.ExpandSeriesContext(context.Background(), list)
+}
+
+// ExpandSeriesContext is like ExpandSeries, but the requests are sent using ctx.
+func (c *Client) ExpandSeriesContext(ctx context.Context, list SeriesList) ([]SeriesSummary, error) {
+	if !list.incomplete() {
+		return list.Items, nil
+	}
+	path, err := c.collectionPath(list.List)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]SeriesSummary, 0, list.Available)
+	err = NewSeriesIterator(ctx, nil, func(ctx context.Context, params *SeriesParams) (*SeriesDataWrapper, *http.Response, error) {
+		wrap := &SeriesDataWrapper{}
+		resp, err := receiveWrapped(ctx, c.sling, path, wrap, params)
+		return wrap, resp, err
+	}).Walk(func(sr Series) error {
+		items = append(items, SeriesSummary{Summary: Summary{ResourceURI: sr.ResourceURI, Name: sr.Title}})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// ExpandStories returns all stories in the list. If the list was truncated,
+// every story is fetched from its CollectionURI.
+func (c *Client) ExpandStories(list StoryList) ([]StorySummary, error) {
+	return c.ExpandStoriesContext(context.Background(), list)
+}
+
+// ExpandStoriesContext is like ExpandStories, but the requests are sent using ctx.
+func (c *Client) ExpandStoriesContext(ctx context.Context, list StoryList) ([]StorySummary, error) {
+	if !list.incomplete() {
+		return list.Items, nil
+	}
+	path, err := c.collectionPath(list.List)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]StorySummary, 0, list.Available)
+	err = NewStoryIterator(ctx, nil, func(ctx context.Context, params *StoryParams) (*StoryDataWrapper, *http.Response, error) {
+		wrap := &StoryDataWrapper{}
+		resp, err := receiveWrapped(ctx, c.sling, path, wrap, params)
+		return wrap, resp, err
+	}).Walk(func(st Story) error {
+		items = append(items, StorySummary{Summary: Summary{ResourceURI: st.ResourceURI, Name: st.Title}, Type: st.Type})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,113 @@
+package marvel_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/dustinrc/marvel"
+	"github.com/stretchr/testify/assert"
+)
+
+// collectionHandler serves total comics, or creators if creators is set,
+// paginated, recording the requested paths.
+type collectionHandler struct {
+	total    int
+	creators bool
+	paths    []string
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (ch *collectionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ch.paths = append(ch.paths, r.URL.Path)
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	results := []map[string]interface{}{}
+	for id := offset + 1; id <= offset+limit && id <= ch.total; id++ {
+		if ch.creators {
+			results = append(results, map[string]interface{}{
+				"id":          id,
+				"resourceURI": fmt.Sprintf("http://gateway.marvel.com/v1/public/creators/%d", id),
+				"fullName":    fmt.Sprintf("Creator #%d", id),
+			})
+			continue
+		}
+		results = append(results, map[string]interface{}{
+			"id":          id,
+			"resourceURI": fmt.Sprintf("http://gateway.marvel.com/v1/public/comics/%d", id),
+			"title":       fmt.Sprintf("Comic #%d", id),
+		})
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"code": 200,
+		"data": map[string]interface{}{
+			"offset":  offset,
+			"limit":   limit,
+			"total":   ch.total,
+			"count":   len(results),
+			"results": results,
+		},
+	})
+}
+
+func TestClientExpand(t *testing.T) {
+	ch := &collectionHandler{total: 150}
+	c := newServerClient(t, &mockAuth{}, ch)
+	collectionURI := "http://gateway.marvel.com/v1/public/characters/1009610/comics"
+
+	t.Run("complete lists are returned as is", func(t *testing.T) {
+		list := marvel.ComicList{
+			List:  marvel.List{Available: 1, Returned: 1, CollectionURI: collectionURI},
+			Items: []marvel.ComicSummary{{Summary: marvel.Summary{Name: "Comic #1"}}},
+		}
+		items, err := c.ExpandComics(list)
+		assert.NoError(t, err)
+		assert.Equal(t, list.Items, items)
+		assert.Empty(t, ch.paths)
+	})
+	t.Run("truncated lists are fetched", func(t *testing.T) {
+		list := marvel.ComicList{
+			List: marvel.List{Available: 150, Returned: 20, CollectionURI: collectionURI},
+		}
+		items, err := c.ExpandComics(list)
+		assert.NoError(t, err)
+		assert.Len(t, items, 150)
+		for i, item := range items {
+			id, err := item.ID()
+			assert.NoError(t, err)
+			assert.Equal(t, i+1, id)
+			assert.Equal(t, fmt.Sprintf("Comic #%d", i+1), item.Name)
+		}
+		assert.Equal(t, []string{
+			"/v1/public/characters/1009610/comics",
+			"/v1/public/characters/1009610/comics",
+		}, ch.paths)
+	})
+	t.Run("truncated creators keep their roles", func(t *testing.T) {
+		c := newServerClient(t, &mockAuth{}, &collectionHandler{total: 25, creators: true})
+		uri := func(id int) string { return fmt.Sprintf("http://gateway.marvel.com/v1/public/creators/%d", id) }
+		list := marvel.CreatorList{
+			List: marvel.List{Available: 25, Returned: 2, CollectionURI: "http://gateway.marvel.com/v1/public/comics/21366/creators"},
+			Items: []marvel.CreatorSummary{
+				{Summary: marvel.Summary{ResourceURI: uri(1), Name: "Creator #1"}, Role: "writer"},
+				{Summary: marvel.Summary{ResourceURI: uri(2), Name: "Creator #2"}, Role: "penciller"},
+			},
+		}
+		items, err := c.ExpandCreators(list)
+		assert.NoError(t, err)
+		assert.Len(t, items, 25)
+		assert.Equal(t, list.Items, items[:2])
+		assert.Equal(t, marvel.CreatorSummary{Summary: marvel.Summary{ResourceURI: uri(3), Name: "Creator #3"}}, items[2])
+	})
+	t.Run("invalid collection URIs are rejected", func(t *testing.T) {
+		ch.paths = nil
+		list := marvel.StoryList{
+			List: marvel.List{Available: 30, Returned: 20, CollectionURI: "http://example.com/comics"},
+		}
+		_, err := c.ExpandStories(list)
+		assert.Error(t, err)
+		assert.Empty(t, ch.paths)
+	})
+}
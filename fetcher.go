@@ -0,0 +1,200 @@
+package marvel
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrPlaceholder is returned when asked to fetch an image which is the API's
+// generic "image not available" graphic.
+var ErrPlaceholder = errors.New("marvel: image is a placeholder")
+
+// mirrorKey identifies an image URL mirrored to a directory.
+type mirrorKey struct {
+	dir string
+	url string
+}
+
+// mirrored is the outcome of mirroring an image URL to a directory, shared by
+// all requests for the same URL and directory.
+type mirrored struct {
+	done     chan struct{}
+	path     string
+	err      error
+	canceled bool
+}
+
+// ImageFetcher downloads images from the API's image servers. Downloads are
+// limited to a number of workers at any time, and images mirrored to a
+// directory are downloaded only once per ImageFetcher.
+type ImageFetcher struct {
+	httpClient *http.Client
+	sem        chan struct{}
+
+	mu     sync.Mutex
+	mirror map[mirrorKey]*mirrored
+}
+
+// NewImageFetcher returns an ImageFetcher using the given http client, or the
+// default if nil, with at most workers downloads at once. A workers value less
+// than 1 is treated as 1.
+func NewImageFetcher(httpClient *http.Client, workers int) *ImageFetcher {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	return &ImageFetcher{
+		httpClient: httpClient,
+		sem:        make(chan struct{}, workers),
+		mirror:     make(map[mirrorKey]*mirrored),
+	}
+}
+
+// Fetch writes the image in the given variant to w. ErrPlaceholder is returned
+// for placeholder images, without downloading them.
+func (f *ImageFetcher) Fetch(ctx context.Context, img Image, variant ImageVariant, w io.Writer) error {
+	if img.IsPlaceholder() {
+		return ErrPlaceholder
+	}
+	return f.download(ctx, img.URL(variant), w)
+}
+
+// Mirror downloads the image in the given variant to dir, naming the file by
+// the SHA-256 hash of its contents, and returns the file's path. An image URL
+// already mirrored to dir by the ImageFetcher is not downloaded again.
+// ErrPlaceholder is returned for placeholder images.
+func (f *ImageFetcher) Mirror(ctx context.Context, img Image, variant ImageVariant, dir string) (string, error) {
+	if img.IsPlaceholder() {
+		return "", ErrPlaceholder
+	}
+	url := img.URL(variant)
+	key := mirrorKey{dir: filepath.Clean(dir), url: url}
+
+	f.mu.Lock()
+	m, ok := f.mirror[key]
+	if !ok {
+		m = &mirrored{done: make(chan struct{})}
+		f.mirror[key] = m
+	}
+	f.mu.Unlock()
+	if ok {
+		select {
+		case <-m.done:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+		if m.canceled {
+			// The request mirroring the image gave up, not the download.
+			return f.Mirror(ctx, img, variant, dir)
+		}
+		return m.path, m.err
+	}
+
+	m.path, m.err = f.mirrorFile(ctx, url, filepath.Ext(url), dir)
+	if m.err != nil {
+		m.canceled = ctx.Err() != nil
+		f.mu.Lock()
+		delete(f.mirror, key)
+		f.mu.Unlock()
+	}
+	close(m.done)
+	return m.path, m.err
+}
+
+// MirrorAll concurrently mirrors each of the images to dir, as Mirror does.
+// Placeholder images are skipped. The returned map holds the path of each
+// mirrored image by its URL. The first error encountered, if any, is returned
+// once all of the images have been attempted.
+func (f *ImageFetcher) MirrorAll(ctx context.Context, images []Image, variant ImageVariant, dir string) (map[string]string, error) {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		paths    = make(map[string]string)
+		firstErr error
+	)
+	for _, img := range images {
+		if img.IsPlaceholder() {
+			continue
+		}
+		wg.Add(1)
+		go func(img Image) {
+			defer wg.Done()
+			path, err := f.Mirror(ctx, img, variant, dir)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			paths[img.URL(variant)] = path
+		}(img)
+	}
+	wg.Wait()
+	return paths, firstErr
+}
+
+// mirrorFile downloads url to a temporary file in dir, which is then renamed
+// after the hash of its contents.
+func (f *ImageFetcher) mirrorFile(ctx context.Context, url, ext, dir string) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	tmp, err := ioutil.TempFile(dir, ".tmp-")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	hasher := sha256.New()
+	err = f.download(ctx, url, io.MultiWriter(tmp, hasher))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, hex.EncodeToString(hasher.Sum(nil))+ext)
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// download writes the body of a successful GET of url to w, waiting for a free
+// worker first.
+func (f *ImageFetcher) download(ctx context.Context, url string, w io.Writer) error {
+	select {
+	case f.sem <- struct{}{}:
+		defer func() { <-f.sem }()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := f.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("marvel: fetching %s: %s", url, resp.Status)
+	}
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
@@ -0,0 +1,188 @@
+package marvel_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dustinrc/marvel"
+	"github.com/stretchr/testify/assert"
+)
+
+// imageServer serves an image's contents as its request path, recording how
+// many requests were made for each path. Requests for paths under /slow are
+// held until release is closed.
+type imageServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests map[string]int
+	inFlight int32
+	peak     int32
+	release  chan struct{}
+}
+
+// newImageServer starts an imageServer over TLS, as image URLs are always
+// normalized to https. The server is closed when the test completes.
+func newImageServer(t *testing.T) *imageServer {
+	is := &imageServer{requests: make(map[string]int), release: make(chan struct{})}
+	is.Server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&is.inFlight, 1)
+		defer atomic.AddInt32(&is.inFlight, -1)
+		for {
+			peak := atomic.LoadInt32(&is.peak)
+			if n <= peak || atomic.CompareAndSwapInt32(&is.peak, peak, n) {
+				break
+			}
+		}
+		is.mu.Lock()
+		is.requests[r.URL.Path]++
+		is.mu.Unlock()
+
+		if r.URL.Path == "/missing/detail.jpg" {
+			http.NotFound(w, r)
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/slow/") {
+			select {
+			case <-is.release:
+			case <-r.Context().Done():
+				return
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		w.Write([]byte("image:" + r.URL.Path))
+	}))
+	t.Cleanup(is.Close)
+	return is
+}
+
+// image returns an Image hosted by the server, with an http path as given by
+// the API.
+func (is *imageServer) image(path string) marvel.Image {
+	return marvel.Image{
+		Path:      "http" + is.URL[len("https"):] + path,
+		Extension: "jpg",
+	}
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestImageFetcherFetch(t *testing.T) {
+	is := newImageServer(t)
+	f := marvel.NewImageFetcher(is.Client(), 2)
+
+	t.Run("image is written", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		err := f.Fetch(context.Background(), is.image("/a/b"), marvel.PortraitXLarge, buf)
+		assert.NoError(t, err)
+		assert.Equal(t, "image:/a/b/portrait_xlarge.jpg", buf.String())
+	})
+	t.Run("placeholders are skipped", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		err := f.Fetch(context.Background(), is.image("/b/40/image_not_available"), marvel.Detail, buf)
+		assert.Equal(t, marvel.ErrPlaceholder, err)
+		assert.Empty(t, buf.String())
+		assert.Zero(t, is.requests["/b/40/image_not_available/detail.jpg"])
+	})
+	t.Run("error responses are returned", func(t *testing.T) {
+		err := f.Fetch(context.Background(), is.image("/missing"), marvel.Detail, &bytes.Buffer{})
+		assert.Error(t, err)
+	})
+}
+
+func TestImageFetcherMirror(t *testing.T) {
+	is := newImageServer(t)
+	dir := t.TempDir()
+	f := marvel.NewImageFetcher(is.Client(), 3)
+
+	images := []marvel.Image{
+		is.image("/1"), is.image("/2"), is.image("/3"), is.image("/4"),
+		is.image("/5"), is.image("/6"), is.image("/1"), is.image("/2"),
+		is.image("/b/40/image_not_available"),
+	}
+	paths, err := f.MirrorAll(context.Background(), images, marvel.Detail, dir)
+	assert.NoError(t, err)
+	assert.Len(t, paths, 6)
+
+	for _, img := range images[:6] {
+		path := paths[img.URL(marvel.Detail)]
+		content := "image:" + img.Path[len(is.URL)-1:] + "/detail.jpg"
+		assert.Equal(t, filepath.Join(dir, sha256Hex(content)+".jpg"), path)
+		b, err := ioutil.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, content, string(b))
+	}
+	for path, count := range is.requests {
+		assert.Equal(t, 1, count, "Image %s downloaded more than once", path)
+	}
+	assert.True(t, is.peak <= 3, "More than 3 images downloaded at once")
+
+	path, err := f.Mirror(context.Background(), is.image("/1"), marvel.Detail, dir)
+	assert.NoError(t, err)
+	assert.Equal(t, paths[is.image("/1").URL(marvel.Detail)], path)
+	assert.Equal(t, 1, is.requests["/1/detail.jpg"], "Mirrored image downloaded again")
+
+	matches, _ := filepath.Glob(filepath.Join(dir, ".tmp-*"))
+	assert.Empty(t, matches, "Temporary files left behind")
+}
+
+func TestImageFetcherMirrorDirs(t *testing.T) {
+	is := newImageServer(t)
+	f := marvel.NewImageFetcher(is.Client(), 2)
+
+	t.Run("image is mirrored to each directory", func(t *testing.T) {
+		dir1, dir2 := t.TempDir(), t.TempDir()
+		path1, err := f.Mirror(context.Background(), is.image("/1"), marvel.Detail, dir1)
+		assert.NoError(t, err)
+		path2, err := f.Mirror(context.Background(), is.image("/1"), marvel.Detail, dir2)
+		assert.NoError(t, err)
+
+		assert.Equal(t, dir1, filepath.Dir(path1))
+		assert.Equal(t, dir2, filepath.Dir(path2))
+		for _, path := range []string{path1, path2} {
+			b, err := ioutil.ReadFile(path)
+			assert.NoError(t, err)
+			assert.Equal(t, "image:/1/detail.jpg", string(b))
+		}
+	})
+	t.Run("waiting mirror outlives a canceled one", func(t *testing.T) {
+		dir := t.TempDir()
+		ctx, cancel := context.WithCancel(context.Background())
+		leaderErr := make(chan error, 1)
+		go func() {
+			_, err := f.Mirror(ctx, is.image("/slow/1"), marvel.Detail, dir)
+			leaderErr <- err
+		}()
+		for atomic.LoadInt32(&is.inFlight) == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		followerPath := make(chan string, 1)
+		go func() {
+			path, err := f.Mirror(context.Background(), is.image("/slow/1"), marvel.Detail, dir)
+			assert.NoError(t, err)
+			followerPath <- path
+		}()
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+		assert.ErrorIs(t, <-leaderErr, context.Canceled)
+		close(is.release)
+
+		path := <-followerPath
+		b, err := ioutil.ReadFile(path)
+		assert.NoError(t, err)
+		assert.Equal(t, "image:/slow/1/detail.jpg", string(b))
+	})
+}
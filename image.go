@@ -0,0 +1,78 @@
+package marvel
+
+import (
+	"strings"
+)
+
+// ImageVariant names a size and aspect ratio in which the API's images are
+// served. See https://developer.marvel.com/documentation/images for details.
+type ImageVariant string
+
+// Portrait aspect ratio variants.
+const (
+	PortraitSmall      ImageVariant = "portrait_small"      // 50x75px
+	PortraitMedium     ImageVariant = "portrait_medium"     // 100x150px
+	PortraitXLarge     ImageVariant = "portrait_xlarge"     // 150x225px
+	PortraitFantastic  ImageVariant = "portrait_fantastic"  // 168x252px
+	PortraitUncanny    ImageVariant = "portrait_uncanny"    // 300x450px
+	PortraitIncredible ImageVariant = "portrait_incredible" // 216x324px
+)
+
+// Standard (square) aspect ratio variants.
+const (
+	StandardSmall     ImageVariant = "standard_small"     // 65x45px
+	StandardMedium    ImageVariant = "standard_medium"    // 100x100px
+	StandardLarge     ImageVariant = "standard_large"     // 140x140px
+	StandardXLarge    ImageVariant = "standard_xlarge"    // 200x200px
+	StandardFantastic ImageVariant = "standard_fantastic" // 250x250px
+	StandardAmazing   ImageVariant = "standard_amazing"   // 180x180px
+)
+
+// Landscape aspect ratio variants.
+const (
+	LandscapeSmall      ImageVariant = "landscape_small"      // 120x90px
+	LandscapeMedium     ImageVariant = "landscape_medium"     // 175x130px
+	LandscapeLarge      ImageVariant = "landscape_large"      // 190x140px
+	LandscapeXLarge     ImageVariant = "landscape_xlarge"     // 270x200px
+	LandscapeAmazing    ImageVariant = "landscape_amazing"    // 250x156px
+	LandscapeIncredible ImageVariant = "landscape_incredible" // 464x261px
+)
+
+// Full size and detail variants. FullSize is the original, unscaled image.
+// Detail is the full image constrained to 500px wide.
+const (
+	FullSize ImageVariant = ""
+	Detail   ImageVariant = "detail"
+)
+
+// placeholderNames are the final path elements of the images the API gives
+// for entities without one of their own.
+var placeholderNames = []string{
+	"image_not_available",
+	"4c002e0305708",
+}
+
+// URL returns the address of the image in the given variant. The API's http
+// paths are normalized to https.
+func (img Image) URL(variant ImageVariant) string {
+	path := img.Path
+	if strings.HasPrefix(path, "http://") {
+		path = "https://" + strings.TrimPrefix(path, "http://")
+	}
+	if variant != FullSize {
+		path += "/" + string(variant)
+	}
+	return path + "." + img.Extension
+}
+
+// IsPlaceholder reports whether the image is the API's generic "image not
+// available" graphic, rather than one specific to the entity.
+func (img Image) IsPlaceholder() bool {
+	name := img.Path[strings.LastIndex(img.Path, "/")+1:]
+	for _, placeholder := range placeholderNames {
+		if name == placeholder {
+			return true
+		}
+	}
+	return false
+}
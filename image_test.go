@@ -0,0 +1,55 @@
+package marvel_test
+
+import (
+	"testing"
+
+	"github.com/dustinrc/marvel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageURL(t *testing.T) {
+	img := marvel.Image{
+		Path:      "http://i.annihil.us/u/prod/marvel/i/mg/3/40/4bb4680432f73",
+		Extension: "jpg",
+	}
+	testCases := []struct {
+		variant  marvel.ImageVariant
+		expected string
+	}{
+		{marvel.PortraitSmall, "https://i.annihil.us/u/prod/marvel/i/mg/3/40/4bb4680432f73/portrait_small.jpg"},
+		{marvel.PortraitUncanny, "https://i.annihil.us/u/prod/marvel/i/mg/3/40/4bb4680432f73/portrait_uncanny.jpg"},
+		{marvel.StandardFantastic, "https://i.annihil.us/u/prod/marvel/i/mg/3/40/4bb4680432f73/standard_fantastic.jpg"},
+		{marvel.LandscapeIncredible, "https://i.annihil.us/u/prod/marvel/i/mg/3/40/4bb4680432f73/landscape_incredible.jpg"},
+		{marvel.Detail, "https://i.annihil.us/u/prod/marvel/i/mg/3/40/4bb4680432f73/detail.jpg"},
+		{marvel.FullSize, "https://i.annihil.us/u/prod/marvel/i/mg/3/40/4bb4680432f73.jpg"},
+	}
+	for _, tC := range testCases {
+		t.Run(string(tC.variant), func(t *testing.T) {
+			assert.Equal(t, tC.expected, img.URL(tC.variant))
+		})
+	}
+
+	t.Run("https paths are unchanged", func(t *testing.T) {
+		secure := marvel.Image{Path: "https://example.com/a/b", Extension: "png"}
+		assert.Equal(t, "https://example.com/a/b/standard_small.png", secure.URL(marvel.StandardSmall))
+	})
+}
+
+func TestImageIsPlaceholder(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		path     string
+		expected bool
+	}{
+		{"image not available", "http://i.annihil.us/u/prod/marvel/i/mg/b/40/image_not_available", true},
+		{"older placeholder", "http://i.annihil.us/u/prod/marvel/i/mg/f/60/4c002e0305708", true},
+		{"entity image", "http://i.annihil.us/u/prod/marvel/i/mg/3/40/4bb4680432f73", false},
+		{"empty path", "", false},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			img := marvel.Image{Path: tC.path, Extension: "jpg"}
+			assert.Equal(t, tC.expected, img.IsPlaceholder())
+		})
+	}
+}
@@ -0,0 +1,586 @@
+package marvel
+
+import (
+	"context"
+	"net/http"
+)
+
+// maxLimit is the largest number of results the API returns per page.
+const maxLimit = 100
+
+// page is a single page of a listing. Results holds the typed result slice,
+// e.g., []Character.
+type page struct {
+	DataContainer
+	Results interface{}
+}
+
+// pageFunc fetches the page of a listing which begins at offset and holds at
+// most limit results.
+type pageFunc func(ctx context.Context, offset, limit int) (*page, error)
+
+// pageResult is the outcome of fetching a page concurrently.
+type pageResult struct {
+	page *page
+	err  error
+}
+
+// pager walks every page of a listing, using the container's Total to know
+// when the final page has been reached. With more than one worker, the pages
+// following the first are fetched concurrently but still returned in order.
+type pager struct {
+	ctx     context.Context
+	fetch   pageFunc
+	offset  int
+	limit   int
+	workers int
+	done    bool
+	err     error
+
+	pending chan chan pageResult
+	cancel  context.CancelFunc
+}
+
+// newPager returns a pager starting at offset. If limit is not positive,
+// pages of the maximum size are requested.
+func newPager(ctx context.Context, offset, limit int, fetch pageFunc) pager {
+	if limit <= 0 || limit > maxLimit {
+		limit = maxLimit
+	}
+	return pager{
+		ctx:    ctx,
+		fetch:  fetch,
+		offset: offset,
+		limit:  limit,
+	}
+}
+
+// next returns the results of the next page, or nil once every page has been
+// fetched or an error has occurred.
+func (p *pager) next() interface{} {
+	if p.pending != nil {
+		return p.nextPending()
+	}
+	if p.done || p.err != nil {
+		return nil
+	}
+	pg, err := p.fetch(p.ctx, p.offset, p.limit)
+	if err != nil {
+		p.err = err
+		return nil
+	}
+	p.offset += pg.Count
+	if pg.Count == 0 || p.offset >= pg.Total {
+		p.done = true
+	} else if p.workers > 1 {
+		p.start(pg.Total)
+	}
+	return pg.Results
+}
+
+// start concurrently fetches the pages from the current offset up to total.
+// At most p.workers pages are fetched or waiting to be returned at any time.
+func (p *pager) start(total int) {
+	ctx, cancel := context.WithCancel(p.ctx)
+	p.cancel = cancel
+	p.pending = make(chan chan pageResult, p.workers-1)
+
+	go func(offset, limit int) {
+		defer close(p.pending)
+		for ; offset < total; offset += limit {
+			res := make(chan pageResult, 1)
+			select {
+			case p.pending <- res:
+			case <-ctx.Done():
+				return
+			}
+			go func(offset int) {
+				pg, err := p.fetch(ctx, offset, limit)
+				res <- pageResult{pg, err}
+			}(offset)
+		}
+	}(p.offset, p.limit)
+}
+
+// stop cancels any pages still being fetched concurrently.
+func (p *pager) stop() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+}
+
+// nextPending returns the results of the next concurrently fetched page.
+func (p *pager) nextPending() interface{} {
+	if p.err != nil {
+		return nil
+	}
+	res, ok := <-p.pending
+	if !ok {
+		p.done = true
+		p.stop()
+		return nil
+	}
+	r := <-res
+	if r.err != nil {
+		p.err = r.err
+		p.stop()
+		return nil
+	}
+	return r.page.Results
+}
+
+// CharacterIterator walks every character of a listing, fetching further pages from the
+// API as necessary. Call Next before each call to Value, and check Err once
+// Next returns false.
+type CharacterIterator struct {
+	pager
+	results []Character
+	value   Character
+}
+
+// NewCharacterIterator returns a CharacterIterator which fetches each page using fn. The
+// given params are copied; only their Offset and Limit are altered per page.
+// It lets implementations of CharacterAPI other than the services return iterators.
+func NewCharacterIterator(ctx context.Context, params *CharacterParams, fn func(context.Context, *CharacterParams) (*CharacterDataWrapper, *http.Response, error)) *CharacterIterator {
+	var p CharacterParams
+	if params != nil {
+		p = *params
+	}
+	return &CharacterIterator{
+		pager: newPager(ctx, p.Offset, p.Limit, func(ctx context.Context, offset, limit int) (*page, error) {
+			pageParams := p
+			pageParams.Offset, pageParams.Limit = offset, limit
+			wrap, _, err := fn(ctx, &pageParams)
+			if err != nil {
+				return nil, err
+			}
+			return &page{wrap.Data.DataContainer, wrap.Data.Results}, nil
+		}),
+	}
+}
+
+// Next advances to the next character, returning false when there are no more
+// or an error occurred.
+func (it *CharacterIterator) Next() bool {
+	for len(it.results) == 0 {
+		results := it.pager.next()
+		if results == nil {
+			return false
+		}
+		it.results = results.([]Character)
+	}
+	it.value, it.results = it.results[0], it.results[1:]
+	return true
+}
+
+// Workers sets the number of pages fetched concurrently once the first page,
+// and with it the total number of results, has been received. Results are
+// still returned in order. Workers must be called before the first call to
+// Next. If iteration is abandoned early, cancel the context the iterator was
+// created with to stop any remaining fetches.
+func (it *CharacterIterator) Workers(n int) *CharacterIterator {
+	it.workers = n
+	return it
+}
+
+// Value returns the current character.
+func (it *CharacterIterator) Value() Character {
+	return it.value
+}
+
+// Err returns the first error encountered while fetching pages, if any.
+func (it *CharacterIterator) Err() error {
+	return it.err
+}
+
+// Walk calls fn for each remaining character. It stops at the first error
+// returned by fn or encountered while fetching pages, and returns it.
+func (it *CharacterIterator) Walk(fn func(Character) error) error {
+	for it.Next() {
+		if err := fn(it.Value()); err != nil {
+			it.stop()
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// ComicIterator walks every comic of a listing, fetching further pages from the
+// API as necessary. Call Next before each call to Value, and check Err once
+// Next returns false.
+type ComicIterator struct {
+	pager
+	results []Comic
+	value   Comic
+}
+
+// NewComicIterator returns a ComicIterator which fetches each page using fn. The
+// given params are copied; only their Offset and Limit are altered per page.
+// It lets implementations of ComicAPI other than the services return iterators.
+func NewComicIterator(ctx context.Context, params *ComicParams, fn func(context.Context, *ComicParams) (*ComicDataWrapper, *http.Response, error)) *ComicIterator {
+	var p ComicParams
+	if params != nil {
+		p = *params
+	}
+	return &ComicIterator{
+		pager: newPager(ctx, p.Offset, p.Limit, func(ctx context.Context, offset, limit int) (*page, error) {
+			pageParams := p
+			pageParams.Offset, pageParams.Limit = offset, limit
+			wrap, _, err := fn(ctx, &pageParams)
+			if err != nil {
+				return nil, err
+			}
+			return &page{wrap.Data.DataContainer, wrap.Data.Results}, nil
+		}),
+	}
+}
+
+// Next advances to the next comic, returning false when there are no more
+// or an error occurred.
+func (it *ComicIterator) Next() bool {
+	for len(it.results) == 0 {
+		results := it.pager.next()
+		if results == nil {
+			return false
+		}
+		it.results = results.([]Comic)
+	}
+	it.value, it.results = it.results[0], it.results[1:]
+	return true
+}
+
+// Workers sets the number of pages fetched concurrently once the first page,
+// and with it the total number of results, has been received. Results are
+// still returned in order. Workers must be called before the first call to
+// Next. If iteration is abandoned early, cancel the context the iterator was
+// created with to stop any remaining fetches.
+func (it *ComicIterator) Workers(n int) *ComicIterator {
+	it.workers = n
+	return it
+}
+
+// Value returns the current comic.
+func (it *ComicIterator) Value() Comic {
+	return it.value
+}
+
+// Err returns the first error encountered while fetching pages, if any.
+func (it *ComicIterator) Err() error {
+	return it.err
+}
+
+// Walk calls fn for each remaining comic. It stops at the first error
+// returned by fn or encountered while fetching pages, and returns it.
+func (it *ComicIterator) Walk(fn func(Comic) error) error {
+	for it.Next() {
+		if err := fn(it.Value()); err != nil {
+			it.stop()
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// CreatorIterator walks every creator of a listing, fetching further pages from the
+// API as necessary. Call Next before each call to Value, and check Err once
+// Next returns false.
+type CreatorIterator struct {
+	pager
+	results []Creator
+	value   Creator
+}
+
+// NewCreatorIterator returns a CreatorIterator which fetches each page using fn. The
+// given params are copied; only their Offset and Limit are altered per page.
+// It lets implementations of CreatorAPI other than the services return iterators.
+func NewCreatorIterator(ctx context.Context, params *CreatorParams, fn func(context.Context, *CreatorParams) (*CreatorDataWrapper, *http.Response, error)) *CreatorIterator {
+	var p CreatorParams
+	if params != nil {
+		p = *params
+	}
+	return &CreatorIterator{
+		pager: newPager(ctx, p.Offset, p.Limit, func(ctx context.Context, offset, limit int) (*page, error) {
+			pageParams := p
+			pageParams.Offset, pageParams.Limit = offset, limit
+			wrap, _, err := fn(ctx, &pageParams)
+			if err != nil {
+				return nil, err
+			}
+			return &page{wrap.Data.DataContainer, wrap.Data.Results}, nil
+		}),
+	}
+}
+
+// Next advances to the next creator, returning false when there are no more
+// or an error occurred.
+func (it *CreatorIterator) Next() bool {
+	for len(it.results) == 0 {
+		results := it.pager.next()
+		if results == nil {
+			return false
+		}
+		it.results = results.([]Creator)
+	}
+	it.value, it.results = it.results[0], it.results[1:]
+	return true
+}
+
+// Workers sets the number of pages fetched concurrently once the first page,
+// and with it the total number of results, has been received. Results are
+// still returned in order. Workers must be called before the first call to
+// Next. If iteration is abandoned early, cancel the context the iterator was
+// created with to stop any remaining fetches.
+func (it *CreatorIterator) Workers(n int) *CreatorIterator {
+	it.workers = n
+	return it
+}
+
+// Value returns the current creator.
+func (it *CreatorIterator) Value() Creator {
+	return it.value
+}
+
+// Err returns the first error encountered while fetching pages, if any.
+func (it *CreatorIterator) Err() error {
+	return it.err
+}
+
+// Walk calls fn for each remaining creator. It stops at the first error
+// returned by fn or encountered while fetching pages, and returns it.
+func (it *CreatorIterator) Walk(fn func(Creator) error) error {
+	for it.Next() {
+		if err := fn(it.Value()); err != nil {
+			it.stop()
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// EventIterator walks every event of a listing, fetching further pages from the
+// API as necessary. Call Next before each call to Value, and check Err once
+// Next returns false.
+type EventIterator struct {
+	pager
+	results []Event
+	value   Event
+}
+
+// NewEventIterator returns an EventIterator which fetches each page using fn. The
+// given params are copied; only their Offset and Limit are altered per page.
+// It lets implementations of EventAPI other than the services return iterators.
+func NewEventIterator(ctx context.Context, params *EventParams, fn func(context.Context, *EventParams) (*EventDataWrapper, *http.Response, error)) *EventIterator {
+	var p EventParams
+	if params != nil {
+		p = *params
+	}
+	return &EventIterator{
+		pager: newPager(ctx, p.Offset, p.Limit, func(ctx context.Context, offset, limit int) (*page, error) {
+			pageParams := p
+			pageParams.Offset, pageParams.Limit = offset, limit
+			wrap, _, err := fn(ctx, &pageParams)
+			if err != nil {
+				return nil, err
+			}
+			return &page{wrap.Data.DataContainer, wrap.Data.Results}, nil
+		}),
+	}
+}
+
+// Next advances to the next event, returning false when there are no more
+// or an error occurred.
+func (it *EventIterator) Next() bool {
+	for len(it.results) == 0 {
+		results := it.pager.next()
+		if results == nil {
+			return false
+		}
+		it.results = results.([]Event)
+	}
+	it.value, it.results = it.results[0], it.results[1:]
+	return true
+}
+
+// Workers sets the number of pages fetched concurrently once the first page,
+// and with it the total number of results, has been received. Results are
+// still returned in order. Workers must be called before the first call to
+// Next. If iteration is abandoned early, cancel the context the iterator was
+// created with to stop any remaining fetches.
+func (it *EventIterator) Workers(n int) *EventIterator {
+	it.workers = n
+	return it
+}
+
+// Value returns the current event.
+func (it *EventIterator) Value() Event {
+	return it.value
+}
+
+// Err returns the first error encountered while fetching pages, if any.
+func (it *EventIterator) Err() error {
+	return it.err
+}
+
+// Walk calls fn for each remaining event. It stops at the first error
+// returned by fn or encountered while fetching pages, and returns it.
+func (it *EventIterator) Walk(fn func(Event) error) error {
+	for it.Next() {
+		if err := fn(it.Value()); err != nil {
+			it.stop()
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// SeriesIterator walks every series of a listing, fetching further pages from the
+// API as necessary. Call Next before each call to Value, and check Err once
+// Next returns false.
+type SeriesIterator struct {
+	pager
+	results []Series
+	value   Series
+}
+
+// NewSeriesIterator returns a SeriesIterator which fetches each page using fn. The
+// given params are copied; only their Offset and Limit are altered per page.
+// It lets implementations of SeriesAPI other than the services return iterators.
+func NewSeriesIterator(ctx context.Context, params *SeriesParams, fn func(context.Context, *SeriesParams) (*SeriesDataWrapper, *http.Response, error)) *SeriesIterator {
+	var p SeriesParams
+	if params != nil {
+		p = *params
+	}
+	return &SeriesIterator{
+		pager: newPager(ctx, p.Offset, p.Limit, func(ctx context.Context, offset, limit int) (*page, error) {
+			pageParams := p
+			pageParams.Offset, pageParams.Limit = offset, limit
+			wrap, _, err := fn(ctx, &pageParams)
+			if err != nil {
+				return nil, err
+			}
+			return &page{wrap.Data.DataContainer, wrap.Data.Results}, nil
+		}),
+	}
+}
+
+// Next advances to the next series, returning false when there are no more
+// or an error occurred.
+func (it *SeriesIterator) Next() bool {
+	for len(it.results) == 0 {
+		results := it.pager.next()
+		if results == nil {
+			return false
+		}
+		it.results = results.([]Series)
+	}
+	it.value, it.results = it.results[0], it.results[1:]
+	return true
+}
+
+// Workers sets the number of pages fetched concurrently once the first page,
+// and with it the total number of results, has been received. Results are
+// still returned in order. Workers must be called before the first call to
+// Next. If iteration is abandoned early, cancel the context the iterator was
+// created with to stop any remaining fetches.
+func (it *SeriesIterator) Workers(n int) *SeriesIterator {
+	it.workers = n
+	return it
+}
+
+// Value returns the current series.
+func (it *SeriesIterator) Value() Series {
+	return it.value
+}
+
+// Err returns the first error encountered while fetching pages, if any.
+func (it *SeriesIterator) Err() error {
+	return it.err
+}
+
+// Walk calls fn for each remaining series. It stops at the first error
+// returned by fn or encountered while fetching pages, and returns it.
+func (it *SeriesIterator) Walk(fn func(Series) error) error {
+	for it.Next() {
+		if err := fn(it.Value()); err != nil {
+			it.stop()
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// StoryIterator walks every story of a listing, fetching further pages from the
+// API as necessary. Call Next before each call to Value, and check Err once
+// Next returns false.
+type StoryIterator struct {
+	pager
+	results []Story
+	value   Story
+}
+
+// NewStoryIterator returns a StoryIterator which fetches each page using fn. The
+// given params are copied; only their Offset and Limit are altered per page.
+// It lets implementations of StoryAPI other than the services return iterators.
+func NewStoryIterator(ctx context.Context, params *StoryParams, fn func(context.Context, *StoryParams) (*StoryDataWrapper, *http.Response, error)) *StoryIterator {
+	var p StoryParams
+	if params != nil {
+		p = *params
+	}
+	return &StoryIterator{
+		pager: newPager(ctx, p.Offset, p.Limit, func(ctx context.Context, offset, limit int) (*page, error) {
+			pageParams := p
+			pageParams.Offset, pageParams.Limit = offset, limit
+			wrap, _, err := fn(ctx, &pageParams)
+			if err != nil {
+				return nil, err
+			}
+			return &page{wrap.Data.DataContainer, wrap.Data.Results}, nil
+		}),
+	}
+}
+
+// Next advances to the next story, returning false when there are no more
+// or an error occurred.
+func (it *StoryIterator) Next() bool {
+	for len(it.results) == 0 {
+		results := it.pager.next()
+		if results == nil {
+			return false
+		}
+		it.results = results.([]Story)
+	}
+	it.value, it.results = it.results[0], it.results[1:]
+	return true
+}
+
+// Workers sets the number of pages fetched concurrently once the first page,
+// and with it the total number of results, has been received. Results are
+// still returned in order. Workers must be called before the first call to
+// Next. If iteration is abandoned early, cancel the context the iterator was
+// created with to stop any remaining fetches.
+func (it *StoryIterator) Workers(n int) *StoryIterator {
+	it.workers = n
+	return it
+}
+
+// Value returns the current story.
+func (it *StoryIterator) Value() Story {
+	return it.value
+}
+
+// Err returns the first error encountered while fetching pages, if any.
+func (it *StoryIterator) Err() error {
+	return it.err
+}
+
+// Walk calls fn for each remaining story. It stops at the first error
+// returned by fn or encountered while fetching pages, and returns it.
+func (it *StoryIterator) Walk(fn func(Story) error) error {
+	for it.Next() {
+		if err := fn(it.Value()); err != nil {
+			it.stop()
+			return err
+		}
+	}
+	return it.Err()
+}
@@ -0,0 +1,245 @@
+package marvel_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dustinrc/marvel"
+	"github.com/stretchr/testify/assert"
+)
+
+// pagedHandler serves a listing of total results, each having an ID equal to
+// its position in the listing plus one. The paths and offsets requested are
+// recorded in the order they are received.
+type pagedHandler struct {
+	total int
+
+	mu      sync.Mutex
+	paths   []string
+	offsets []int
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (ph *pagedHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit == 0 {
+		limit = 20
+	}
+
+	ph.mu.Lock()
+	ph.paths = append(ph.paths, r.URL.Path)
+	ph.offsets = append(ph.offsets, offset)
+	ph.mu.Unlock()
+
+	results := []map[string]int{}
+	for id := offset + 1; id <= offset+limit && id <= ph.total; id++ {
+		results = append(results, map[string]int{"id": id})
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"code": 200,
+		"data": map[string]interface{}{
+			"offset":  offset,
+			"limit":   limit,
+			"total":   ph.total,
+			"count":   len(results),
+			"results": results,
+		},
+	})
+}
+
+func TestCharacterIterator(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		total    int
+		params   *marvel.CharacterParams
+		offsets  []int
+		firstID  int
+		expected int
+	}{
+		{
+			desc:     "no results",
+			total:    0,
+			offsets:  []int{0},
+			expected: 0,
+		},
+		{
+			desc:     "single partial page",
+			total:    42,
+			offsets:  []int{0},
+			firstID:  1,
+			expected: 42,
+		},
+		{
+			desc:     "several pages of the maximum size",
+			total:    250,
+			offsets:  []int{0, 100, 200},
+			firstID:  1,
+			expected: 250,
+		},
+		{
+			desc:     "limit and offset are honored",
+			total:    100,
+			params:   &marvel.CharacterParams{Limit: 30, Offset: 15},
+			offsets:  []int{15, 45, 75},
+			firstID:  16,
+			expected: 85,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ph := &pagedHandler{total: tC.total}
+			c := newServerClient(t, &mockAuth{}, ph)
+
+			var ids []int
+			it := c.Characters.AllIter(tC.params)
+			for it.Next() {
+				ids = append(ids, it.Value().ID)
+			}
+
+			assert.NoError(t, it.Err())
+			assert.Len(t, ids, tC.expected)
+			if tC.expected > 0 {
+				assert.Equal(t, tC.firstID, ids[0])
+				assert.Equal(t, tC.total, ids[len(ids)-1])
+			}
+			assert.Equal(t, tC.offsets, ph.offsets)
+		})
+	}
+}
+
+func TestIteratorAPIError(t *testing.T) {
+	ph := &pagedHandler{total: 300}
+	c := newServerClient(t, &mockAuth{},
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("offset") == "100" {
+				w.WriteHeader(http.StatusConflict)
+				fmt.Fprint(w, `{"code": 409, "message": "too deep"}`)
+				return
+			}
+			ph.ServeHTTP(w, r)
+		}))
+
+	count := 0
+	it := c.Comics.AllIter(nil)
+	for it.Next() {
+		count++
+	}
+
+	assert.Equal(t, 100, count)
+	assert.EqualError(t, it.Err(), "marvel: 409 too deep")
+	assert.False(t, it.Next(), "Next should remain false after an error")
+}
+
+func TestSubResourceIterator(t *testing.T) {
+	ph := &pagedHandler{total: 120}
+	c := newServerClient(t, &mockAuth{}, ph)
+
+	var stories []marvel.Story
+	it := c.Series.StoriesIter(2258, &marvel.StoryParams{OrderBy: "id"})
+	for it.Next() {
+		stories = append(stories, it.Value())
+	}
+
+	assert.NoError(t, it.Err())
+	assert.Len(t, stories, 120)
+	assert.Equal(t, []string{"/v1/public/series/2258/stories", "/v1/public/series/2258/stories"}, ph.paths)
+}
+
+func TestWalk(t *testing.T) {
+	t.Run("every result is visited", func(t *testing.T) {
+		c := newServerClient(t, &mockAuth{}, &pagedHandler{total: 205})
+
+		sum := 0
+		err := c.Events.Walk(nil, func(ev marvel.Event) error {
+			sum += ev.ID
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 205*206/2, sum)
+	})
+	t.Run("walk stops at the first error", func(t *testing.T) {
+		ph := &pagedHandler{total: 500}
+		c := newServerClient(t, &mockAuth{}, ph)
+
+		errStop := errors.New("stop")
+		visited := 0
+		err := c.Creators.Walk(nil, func(cr marvel.Creator) error {
+			visited++
+			if cr.ID == 150 {
+				return errStop
+			}
+			return nil
+		})
+		assert.Equal(t, errStop, err)
+		assert.Equal(t, 150, visited)
+		assert.Equal(t, []int{0, 100}, ph.offsets)
+	})
+}
+
+func TestIteratorWorkers(t *testing.T) {
+	t.Run("results are returned in offset order", func(t *testing.T) {
+		ph := &pagedHandler{total: 1234}
+		var inFlight, maxInFlight int32
+		c := newServerClient(t, &mockAuth{},
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&inFlight, 1)
+				defer atomic.AddInt32(&inFlight, -1)
+				for {
+					peak := atomic.LoadInt32(&maxInFlight)
+					if n <= peak || atomic.CompareAndSwapInt32(&maxInFlight, peak, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				ph.ServeHTTP(w, r)
+			}))
+
+		var ids []int
+		err := c.Comics.AllIter(&marvel.ComicParams{Limit: 50}).Workers(4).Walk(func(co marvel.Comic) error {
+			ids = append(ids, co.ID)
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, ids, 1234)
+		for i, id := range ids {
+			if !assert.Equal(t, i+1, id, "Results out of order") {
+				break
+			}
+		}
+		assert.Len(t, ph.offsets, 25)
+		assert.Equal(t, 0, ph.offsets[0], "First page must be fetched alone")
+		assert.True(t, maxInFlight > 1, "Pages were not fetched concurrently")
+		assert.True(t, maxInFlight <= 4, "More than 4 pages fetched concurrently")
+	})
+	t.Run("an error stops iteration in order", func(t *testing.T) {
+		ph := &pagedHandler{total: 1000}
+		c := newServerClient(t, &mockAuth{},
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("offset") == "500" {
+					w.WriteHeader(http.StatusInternalServerError)
+					fmt.Fprint(w, `{"code": 500, "message": "oops"}`)
+					return
+				}
+				ph.ServeHTTP(w, r)
+			}))
+
+		var ids []int
+		it := c.Series.CharactersIter(1, nil).Workers(3)
+		for it.Next() {
+			ids = append(ids, it.Value().ID)
+		}
+
+		assert.EqualError(t, it.Err(), "marvel: 500 oops")
+		assert.Len(t, ids, 500)
+		assert.Equal(t, 500, ids[len(ids)-1])
+	})
+}
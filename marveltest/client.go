@@ -0,0 +1,38 @@
+package marveltest
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/dustinrc/marvel"
+)
+
+// Keys with which the Clients returned by NewClient authenticate.
+const (
+	PublicKey  = "marveltest-public"
+	PrivateKey = "marveltest-private"
+)
+
+// NewClient returns a marvel.Client whose requests are served by the store, in
+// memory, rather than sent to the API.
+func NewClient(store *Store) *marvel.Client {
+	httpClient := &http.Client{Transport: &transport{handler: store}}
+	return marvel.NewClient(marvel.NewServerSideAuth(PublicKey, PrivateKey), httpClient)
+}
+
+// transport is an http.RoundTripper which serves requests with a handler.
+type transport struct {
+	handler http.Handler
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+	rec := httptest.NewRecorder()
+	t.handler.ServeHTTP(rec, req)
+	resp := rec.Result()
+	resp.Request = req
+	return resp, nil
+}
@@ -0,0 +1,210 @@
+package marveltest
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dustinrc/marvel"
+)
+
+// basePath is the path under which the API's endpoints are served.
+const basePath = "/v1/public/"
+
+// singular names a single entity of each resource, as in the API's errors.
+var singular = map[marvel.Resource]string{
+	marvel.CharactersResource: "character",
+	marvel.ComicsResource:     "comic",
+	marvel.CreatorsResource:   "creator",
+	marvel.EventsResource:     "event",
+	marvel.SeriesResource:     "series",
+	marvel.StoriesResource:    "story",
+}
+
+// subresources are the listings available for each entity of a resource.
+var subresources = map[marvel.Resource][]marvel.Resource{
+	marvel.CharactersResource: {marvel.ComicsResource, marvel.EventsResource, marvel.SeriesResource, marvel.StoriesResource},
+	marvel.ComicsResource:     {marvel.CharactersResource, marvel.CreatorsResource, marvel.EventsResource, marvel.StoriesResource},
+	marvel.CreatorsResource:   {marvel.ComicsResource, marvel.EventsResource, marvel.SeriesResource, marvel.StoriesResource},
+	marvel.EventsResource:     {marvel.CharactersResource, marvel.ComicsResource, marvel.CreatorsResource, marvel.SeriesResource, marvel.StoriesResource},
+	marvel.SeriesResource:     {marvel.CharactersResource, marvel.ComicsResource, marvel.CreatorsResource, marvel.EventsResource, marvel.StoriesResource},
+	marvel.StoriesResource:    {marvel.CharactersResource, marvel.ComicsResource, marvel.CreatorsResource, marvel.EventsResource, marvel.SeriesResource},
+}
+
+// apiError is an error reply, as given by the API. Its code is a string for
+// some errors and the status code for others.
+type apiError struct {
+	status  int
+	Code    interface{} `json:"code"`
+	Message string      `json:"message,omitempty"`
+	Status  string      `json:"status,omitempty"`
+}
+
+// resourceNotFound returns the error the API gives for unknown endpoints.
+func resourceNotFound(path string) *apiError {
+	return &apiError{
+		status:  http.StatusNotFound,
+		Code:    "ResourceNotFound",
+		Message: fmt.Sprintf("%s does not exist", path),
+	}
+}
+
+// entityNotFound returns the error the API gives for unknown IDs.
+func entityNotFound(resource marvel.Resource) *apiError {
+	return &apiError{
+		status: http.StatusNotFound,
+		Code:   http.StatusNotFound,
+		Status: fmt.Sprintf("We couldn't find that %s", singular[resource]),
+	}
+}
+
+// dataWrapper is a successful reply, as given by the API.
+type dataWrapper struct {
+	Code            int           `json:"code"`
+	Status          string        `json:"status"`
+	Copyright       string        `json:"copyright"`
+	AttributionText string        `json:"attributionText"`
+	AttributionHTML string        `json:"attributionHTML"`
+	ETag            string        `json:"etag"`
+	Data            dataContainer `json:"data"`
+}
+
+// dataContainer is the page of results of a successful reply.
+type dataContainer struct {
+	Offset  int           `json:"offset"`
+	Limit   int           `json:"limit"`
+	Total   int           `json:"total"`
+	Count   int           `json:"count"`
+	Results []interface{} `json:"results"`
+}
+
+// ServeHTTP implements the http.Handler interface, serving the API's
+// endpoints under /v1/public/ from the store. Authentication parameters are
+// ignored.
+func (s *Store) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, &apiError{
+			status:  http.StatusMethodNotAllowed,
+			Code:    "MethodNotAllowed",
+			Message: fmt.Sprintf("%s is not allowed", r.Method),
+		})
+		return
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	data, apiErr := s.serve(r)
+	if apiErr != nil {
+		writeError(w, apiErr)
+		return
+	}
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sum := sha1.Sum(body)
+	etag := hex.EncodeToString(sum[:])
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	writeJSON(w, http.StatusOK, &dataWrapper{
+		Code:            http.StatusOK,
+		Status:          "Ok",
+		Copyright:       "© Marvel",
+		AttributionText: "Data provided by Marvel. © Marvel",
+		AttributionHTML: `<a href="http://marvel.com">Data provided by Marvel. © Marvel</a>`,
+		ETag:            etag,
+		Data:            *data,
+	})
+}
+
+// serve returns the page of results requested, or the error the API would
+// give instead.
+func (s *Store) serve(r *http.Request) (*dataContainer, *apiError) {
+	if !strings.HasPrefix(r.URL.Path, basePath) {
+		return nil, resourceNotFound(r.URL.Path)
+	}
+	segments := strings.Split(strings.TrimPrefix(r.URL.Path, basePath), "/")
+	resource := marvel.Resource(segments[0])
+	if _, ok := singular[resource]; !ok || len(segments) > 3 {
+		return nil, resourceNotFound(r.URL.Path)
+	}
+	if len(segments) == 1 {
+		return s.list(resource, s.records(resource), r)
+	}
+
+	id, err := strconv.Atoi(segments[1])
+	if err != nil {
+		return nil, resourceNotFound(r.URL.Path)
+	}
+	entity := s.record(resource, id)
+	if entity == nil {
+		return nil, entityNotFound(resource)
+	}
+	if len(segments) == 2 {
+		return &dataContainer{Limit: defaultLimit, Total: 1, Count: 1, Results: []interface{}{entity.value}}, nil
+	}
+
+	sub := marvel.Resource(segments[2])
+	if !containsResource(subresources[resource], sub) {
+		return nil, resourceNotFound(r.URL.Path)
+	}
+	children := s.records(sub)
+	related := children[:0]
+	for _, child := range children {
+		if linked(child, sub, entity, resource, id) {
+			related = append(related, child)
+		}
+	}
+	return s.list(sub, related, r)
+}
+
+// list returns the page of the records requested by the query parameters.
+func (s *Store) list(resource marvel.Resource, records []*record, r *http.Request) (*dataContainer, *apiError) {
+	q, apiErr := s.parseQuery(resource, r.URL.Query())
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	matched := records[:0]
+	for _, rec := range records {
+		if q.match(rec) {
+			matched = append(matched, rec)
+		}
+	}
+	q.sort(matched)
+
+	data := &dataContainer{Offset: q.offset, Limit: q.limit, Total: len(matched), Results: []interface{}{}}
+	for i := q.offset; i < len(matched) && i < q.offset+q.limit; i++ {
+		data.Results = append(data.Results, matched[i].value)
+	}
+	data.Count = len(data.Results)
+	return data, nil
+}
+
+// containsResource reports whether the resource is one of resources.
+func containsResource(resources []marvel.Resource, resource marvel.Resource) bool {
+	for _, r := range resources {
+		if r == resource {
+			return true
+		}
+	}
+	return false
+}
+
+// writeError writes the error as the API's reply.
+func writeError(w http.ResponseWriter, apiErr *apiError) {
+	writeJSON(w, apiErr.status, apiErr)
+}
+
+// writeJSON writes v as a JSON reply with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,365 @@
+package marveltest
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustinrc/marvel"
+)
+
+// The API's limits on listings.
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+	maxIDs       = 10
+)
+
+// authParams are the authentication parameters, which are not filters.
+var authParams = map[string]bool{"ts": true, "apikey": true, "hash": true}
+
+// queryParams are the parameters accepted when listing each resource, named
+// by the url tags of its params type.
+var queryParams = map[marvel.Resource]map[string]bool{
+	marvel.CharactersResource: paramNames(marvel.CharacterParams{}),
+	marvel.ComicsResource:     paramNames(marvel.ComicParams{}),
+	marvel.CreatorsResource:   paramNames(marvel.CreatorParams{}),
+	marvel.EventsResource:     paramNames(marvel.EventParams{}),
+	marvel.SeriesResource:     paramNames(marvel.SeriesParams{}),
+	marvel.StoriesResource:    paramNames(marvel.StoryParams{}),
+}
+
+// orderFields are the fields by which each resource can be ordered.
+var orderFields = map[marvel.Resource][]string{
+	marvel.CharactersResource: {"name", "modified"},
+	marvel.ComicsResource:     {"focDate", "onsaleDate", "title", "issueNumber", "modified"},
+	marvel.CreatorsResource:   {"lastName", "firstName", "middleName", "suffix", "modified"},
+	marvel.EventsResource:     {"name", "startDate", "modified"},
+	marvel.SeriesResource:     {"title", "startYear", "modified"},
+	marvel.StoriesResource:    {"id", "modified"},
+}
+
+// textParams are the parameters matched, ignoring case, against the text of a
+// record. Each may also be matched as a prefix by appending "StartsWith".
+var textParams = map[string]bool{
+	"name": true, "title": true, "firstName": true, "middleName": true, "lastName": true,
+	"suffix": true, "format": true, "formatType": true, "diamondCode": true, "upc": true,
+	"isbn": true, "ean": true, "issn": true, "seriesType": true,
+}
+
+// intParams are the parameters matched against the numbers of a record.
+var intParams = map[string]bool{"issueNumber": true, "digitalId": true, "startYear": true}
+
+// paramNames returns the query parameter names of the params struct's fields.
+func paramNames(params interface{}) map[string]bool {
+	names := make(map[string]bool)
+	t := reflect.TypeOf(params)
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("url"), ",")[0]
+		names[name] = true
+	}
+	return names
+}
+
+// filter reports whether a record matches a query parameter.
+type filter func(*record) bool
+
+// order is one of the fields by which a listing is ordered.
+type order struct {
+	field string
+	desc  bool
+}
+
+// query is a parsed listing request.
+type query struct {
+	filters []filter
+	orders  []order
+	limit   int
+	offset  int
+}
+
+// match reports whether the record matches all of the query's filters.
+func (q *query) match(r *record) bool {
+	for _, f := range q.filters {
+		if !f(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// sort orders the records by the query's orders, then by their positions in
+// the store.
+func (q *query) sort(records []*record) {
+	sort.SliceStable(records, func(i, j int) bool {
+		for _, o := range q.orders {
+			c := compare(records[i], records[j], o.field)
+			if o.desc {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return records[i].position < records[j].position
+	})
+}
+
+// compare compares the field of two records, returning -1, 0 or +1.
+func compare(a, b *record, field string) int {
+	if t, ok := a.times[field]; ok {
+		u := b.times[field]
+		switch {
+		case t.Before(u):
+			return -1
+		case t.After(u):
+			return 1
+		}
+		return 0
+	}
+	if n, ok := a.ints[field]; ok {
+		m := b.ints[field]
+		switch {
+		case n < m:
+			return -1
+		case n > m:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(strings.ToLower(a.text[field]), strings.ToLower(b.text[field]))
+}
+
+// parseQuery parses the parameters of a listing of the resource, returning
+// the error the API would give for any it rejects. The store must be locked
+// for reading.
+func (s *Store) parseQuery(resource marvel.Resource, values url.Values) (*query, *apiError) {
+	q := &query{limit: defaultLimit}
+	for key := range values {
+		if authParams[key] {
+			continue
+		}
+		if !queryParams[resource][key] {
+			return nil, conflict("We don't recognize the parameter %s", key)
+		}
+		value := values.Get(key)
+		if value == "" {
+			return nil, conflict("%s cannot be blank if it is set.", key)
+		}
+		if err := s.parseParam(q, resource, key, value); err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+// parseParam adds the parameter to the query.
+func (s *Store) parseParam(q *query, resource marvel.Resource, key, value string) *apiError {
+	switch key {
+	case "limit":
+		limit, err := strconv.Atoi(value)
+		switch {
+		case err != nil || limit < 1:
+			return conflict("You must pass an integer limit greater than 0.")
+		case limit > maxLimit:
+			return conflict("You may not request more than %d items.", maxLimit)
+		}
+		q.limit = limit
+	case "offset":
+		offset, err := strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			return conflict("You must pass an integer offset of 0 or more.")
+		}
+		q.offset = offset
+	case "orderBy":
+		for _, field := range strings.Split(value, ",") {
+			o := order{field: strings.TrimPrefix(field, "-"), desc: strings.HasPrefix(field, "-")}
+			if !contains(orderFields[resource], o.field) {
+				return conflict("'%s' is not a valid ordering parameter.", field)
+			}
+			q.orders = append(q.orders, o)
+		}
+	case "modifiedSince":
+		since, err := parseTime(value)
+		if err != nil {
+			return conflict("You must pass a valid date for modifiedSince.")
+		}
+		q.filters = append(q.filters, func(r *record) bool {
+			return !r.times["modified"].Before(since)
+		})
+	case "dateRange":
+		dates := strings.Split(value, ",")
+		if len(dates) != 2 {
+			return conflict("You must pass both a start and end date in a date range.")
+		}
+		start, err := time.Parse("2006-01-02", dates[0])
+		end, endErr := time.Parse("2006-01-02", dates[1])
+		if err != nil || endErr != nil || end.Before(start) {
+			return conflict("You must pass valid dates, in order, in a date range.")
+		}
+		q.filters = append(q.filters, onSaleBetween(start, end.AddDate(0, 0, 1)))
+	case "dateDescriptor":
+		start, end, ok := descriptorRange(value, time.Now())
+		if !ok {
+			return conflict("'%s' is not a valid date descriptor.", value)
+		}
+		q.filters = append(q.filters, onSaleBetween(start, end))
+	case "noVariants", "hasDigitalIssue":
+		if value != "true" {
+			break
+		}
+		if key == "noVariants" {
+			q.filters = append(q.filters, func(r *record) bool { return r.text["variantDescription"] == "" })
+		} else {
+			q.filters = append(q.filters, func(r *record) bool { return r.ints["digitalId"] > 0 })
+		}
+	case "sharedAppearances", "collaborators":
+		ids, err := parseIDs(key, value)
+		if err != nil {
+			return err
+		}
+		related := marvel.CharactersResource
+		if key == "collaborators" {
+			related = marvel.CreatorsResource
+		}
+		others := s.lookup(related, ids)
+		q.filters = append(q.filters, func(r *record) bool {
+			for i, id := range ids {
+				if !linked(r, resource, others[i], related, id) {
+					return false
+				}
+			}
+			return true
+		})
+	case "contains":
+		formats := strings.Split(value, ",")
+		q.filters = append(q.filters, func(r *record) bool {
+			for _, id := range r.related[marvel.ComicsResource] {
+				if co, ok := s.comics[id]; ok && contains(formats, co.Format) {
+					return true
+				}
+			}
+			return false
+		})
+	case "characters", "comics", "creators", "events", "series", "stories":
+		ids, err := parseIDs(key, value)
+		if err != nil {
+			return err
+		}
+		related := marvel.Resource(key)
+		others := s.lookup(related, ids)
+		q.filters = append(q.filters, func(r *record) bool {
+			for i, id := range ids {
+				if linked(r, resource, others[i], related, id) {
+					return true
+				}
+			}
+			return false
+		})
+	default:
+		if intParams[key] {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return conflict("You must pass an integer for %s.", key)
+			}
+			q.filters = append(q.filters, func(r *record) bool { return r.ints[key] == n })
+			break
+		}
+		if field := strings.TrimSuffix(key, "StartsWith"); field != key {
+			prefix := strings.ToLower(value)
+			q.filters = append(q.filters, func(r *record) bool {
+				return strings.HasPrefix(strings.ToLower(r.text[field]), prefix)
+			})
+			break
+		}
+		if textParams[key] {
+			q.filters = append(q.filters, func(r *record) bool { return strings.EqualFold(r.text[key], value) })
+		}
+	}
+	return nil
+}
+
+// lookup returns the records of the resource's entities with the given IDs,
+// with nil for any there are none of. The store must be locked for reading.
+func (s *Store) lookup(resource marvel.Resource, ids []int) []*record {
+	records := make([]*record, len(ids))
+	for i, id := range ids {
+		records[i] = s.record(resource, id)
+	}
+	return records
+}
+
+// contains reports whether the value is one of values.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIDs parses a comma-separated list of IDs.
+func parseIDs(key, value string) ([]int, *apiError) {
+	strs := strings.Split(value, ",")
+	if len(strs) > maxIDs {
+		return nil, conflict("You may not submit more than %d %s ids.", maxIDs, key)
+	}
+	ids := make([]int, len(strs))
+	for i, str := range strs {
+		id, err := strconv.Atoi(str)
+		if err != nil {
+			return nil, conflict("You must pass a comma-separated list of integers for %s.", key)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// parseTime parses a time given in a query, in any of the layouts the API
+// accepts.
+func parseTime(value string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02T15:04:05-0700", time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("marveltest: invalid time %q", value)
+}
+
+// onSaleBetween returns a filter for comics on sale from start until end.
+func onSaleBetween(start, end time.Time) filter {
+	return func(r *record) bool {
+		onSale, ok := r.times["onsaleDate"]
+		return ok && !onSale.Before(start) && onSale.Before(end)
+	}
+}
+
+// descriptorRange returns the range of on-sale dates named by a date
+// descriptor, relative to now. Weeks start on Sunday.
+func descriptorRange(descriptor string, now time.Time) (start, end time.Time, ok bool) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	week := today.AddDate(0, 0, -int(today.Weekday()))
+	switch marvel.DateDescriptor(descriptor) {
+	case marvel.DateDescriptorLastWeek:
+		return week.AddDate(0, 0, -7), week, true
+	case marvel.DateDescriptorThisWeek:
+		return week, week.AddDate(0, 0, 7), true
+	case marvel.DateDescriptorNextWeek:
+		return week.AddDate(0, 0, 7), week.AddDate(0, 0, 14), true
+	case marvel.DateDescriptorThisMonth:
+		month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		return month, month.AddDate(0, 1, 0), true
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// conflict returns the 409 Conflict error the API gives for rejected
+// parameters.
+func conflict(format string, args ...interface{}) *apiError {
+	return &apiError{status: 409, Code: 409, Status: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,299 @@
+package marveltest
+
+import (
+	"time"
+
+	"github.com/dustinrc/marvel"
+)
+
+// record is an entity as seen by the fake API's filters and orders, which
+// refer to its attributes by their query parameter names.
+type record struct {
+	id       int
+	position int
+	value    interface{}
+	text     map[string]string
+	ints     map[string]int
+	times    map[string]time.Time
+	related  map[marvel.Resource][]int
+}
+
+// newRecord returns a record of the entity with the given ID and modification
+// time.
+func newRecord(value interface{}, id int, modified marvel.Time) *record {
+	return &record{
+		id:      id,
+		value:   value,
+		text:    make(map[string]string),
+		ints:    map[string]int{"id": id},
+		times:   map[string]time.Time{"modified": modified.Time},
+		related: make(map[marvel.Resource][]int),
+	}
+}
+
+// relates reports whether the record lists any of the IDs of the resource.
+func (r *record) relates(resource marvel.Resource, ids ...int) bool {
+	for _, related := range r.related[resource] {
+		for _, id := range ids {
+			if related == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// linked reports whether r, of the resource, and the entity of other with the
+// given ID, whose record may be nil, are related. Either may list the other.
+func linked(r *record, resource marvel.Resource, other *record, otherResource marvel.Resource, id int) bool {
+	return r.relates(otherResource, id) || other != nil && other.relates(resource, r.id)
+}
+
+// summaryIDs returns the IDs of the summaries, skipping any without one.
+func summaryIDs(summaries []marvel.Summary) []int {
+	ids := make([]int, 0, len(summaries))
+	for _, s := range summaries {
+		if id, err := s.ID(); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// characterIDs returns the IDs of the list's characters.
+func characterIDs(l marvel.CharacterList) []int {
+	summaries := make([]marvel.Summary, len(l.Items))
+	for i, item := range l.Items {
+		summaries[i] = item.Summary
+	}
+	return summaryIDs(summaries)
+}
+
+// comicIDs returns the IDs of the list's comics.
+func comicIDs(l marvel.ComicList) []int {
+	summaries := make([]marvel.Summary, len(l.Items))
+	for i, item := range l.Items {
+		summaries[i] = item.Summary
+	}
+	return summaryIDs(summaries)
+}
+
+// creatorIDs returns the IDs of the list's creators.
+func creatorIDs(l marvel.CreatorList) []int {
+	summaries := make([]marvel.Summary, len(l.Items))
+	for i, item := range l.Items {
+		summaries[i] = item.Summary
+	}
+	return summaryIDs(summaries)
+}
+
+// eventIDs returns the IDs of the list's events.
+func eventIDs(l marvel.EventList) []int {
+	summaries := make([]marvel.Summary, len(l.Items))
+	for i, item := range l.Items {
+		summaries[i] = item.Summary
+	}
+	return summaryIDs(summaries)
+}
+
+// seriesIDs returns the IDs of the list's series.
+func seriesIDs(l marvel.SeriesList) []int {
+	summaries := make([]marvel.Summary, len(l.Items))
+	for i, item := range l.Items {
+		summaries[i] = item.Summary
+	}
+	return summaryIDs(summaries)
+}
+
+// storyIDs returns the IDs of the list's stories.
+func storyIDs(l marvel.StoryList) []int {
+	summaries := make([]marvel.Summary, len(l.Items))
+	for i, item := range l.Items {
+		summaries[i] = item.Summary
+	}
+	return summaryIDs(summaries)
+}
+
+// characterRecord returns the record of the character.
+func characterRecord(ch marvel.Character) *record {
+	r := newRecord(ch, ch.ID, ch.Modified)
+	r.text["name"] = ch.Name
+	r.related[marvel.ComicsResource] = comicIDs(ch.Comics)
+	r.related[marvel.EventsResource] = eventIDs(ch.Events)
+	r.related[marvel.SeriesResource] = seriesIDs(ch.Series)
+	r.related[marvel.StoriesResource] = storyIDs(ch.Stories)
+	return r
+}
+
+// collectionFormats are the comic formats which collect other issues.
+var collectionFormats = map[string]bool{
+	string(marvel.FormatTradePaperback): true,
+	string(marvel.FormatHardcover):      true,
+	string(marvel.FormatDigest):         true,
+	string(marvel.FormatGraphicNovel):   true,
+}
+
+// comicRecord returns the record of the comic.
+func comicRecord(co marvel.Comic) *record {
+	r := newRecord(co, co.ID, co.Modified)
+	r.text["title"] = co.Title
+	r.text["format"] = co.Format
+	r.text["formatType"] = string(marvel.FormatTypeComic)
+	if collectionFormats[co.Format] {
+		r.text["formatType"] = string(marvel.FormatTypeCollection)
+	}
+	r.text["diamondCode"] = co.DiamondCode
+	r.text["upc"] = co.UPC
+	r.text["isbn"] = co.ISBN
+	r.text["ean"] = co.EAN
+	r.text["issn"] = co.ISSN
+	r.text["variantDescription"] = co.VariantDescription
+	r.ints["issueNumber"] = co.IssueNumber
+	r.ints["digitalId"] = co.DigitalID
+	for _, d := range co.Dates {
+		r.times[d.Type] = d.Date.Time
+	}
+	if co.Series != nil {
+		r.related[marvel.SeriesResource] = summaryIDs([]marvel.Summary{co.Series.Summary})
+	}
+	r.related[marvel.CharactersResource] = characterIDs(co.Characters)
+	r.related[marvel.CreatorsResource] = creatorIDs(co.Creators)
+	r.related[marvel.EventsResource] = eventIDs(co.Events)
+	r.related[marvel.StoriesResource] = storyIDs(co.Stories)
+	return r
+}
+
+// creatorRecord returns the record of the creator.
+func creatorRecord(ctr marvel.Creator) *record {
+	r := newRecord(ctr, ctr.ID, ctr.Modified)
+	r.text["name"] = ctr.FullName
+	r.text["firstName"] = ctr.FirstName
+	r.text["middleName"] = ctr.MiddleName
+	r.text["lastName"] = ctr.LastName
+	r.text["suffix"] = ctr.Suffix
+	r.related[marvel.ComicsResource] = comicIDs(ctr.Comics)
+	r.related[marvel.EventsResource] = eventIDs(ctr.Events)
+	r.related[marvel.SeriesResource] = seriesIDs(ctr.Series)
+	r.related[marvel.StoriesResource] = storyIDs(ctr.Stories)
+	return r
+}
+
+// eventRecord returns the record of the event.
+func eventRecord(ev marvel.Event) *record {
+	r := newRecord(ev, ev.ID, ev.Modified)
+	r.text["name"] = ev.Title
+	r.times["startDate"] = ev.Start.Time
+	r.related[marvel.CharactersResource] = characterIDs(ev.Characters)
+	r.related[marvel.ComicsResource] = comicIDs(ev.Comics)
+	r.related[marvel.CreatorsResource] = creatorIDs(ev.Creators)
+	r.related[marvel.SeriesResource] = seriesIDs(ev.Series)
+	r.related[marvel.StoriesResource] = storyIDs(ev.Stories)
+	return r
+}
+
+// seriesRecord returns the record of the series.
+func seriesRecord(sr marvel.Series) *record {
+	r := newRecord(sr, sr.ID, sr.Modified)
+	r.text["title"] = sr.Title
+	r.text["seriesType"] = sr.Type
+	r.ints["startYear"] = sr.StartYear
+	r.related[marvel.CharactersResource] = characterIDs(sr.Characters)
+	r.related[marvel.ComicsResource] = comicIDs(sr.Comics)
+	r.related[marvel.CreatorsResource] = creatorIDs(sr.Creators)
+	r.related[marvel.EventsResource] = eventIDs(sr.Events)
+	r.related[marvel.StoriesResource] = storyIDs(sr.Stories)
+	return r
+}
+
+// storyRecord returns the record of the story. Its original issue counts
+// among its comics.
+func storyRecord(st marvel.Story) *record {
+	r := newRecord(st, st.ID, st.Modified)
+	if st.OriginalIssue != nil {
+		r.related[marvel.ComicsResource] = summaryIDs([]marvel.Summary{st.OriginalIssue.Summary})
+	}
+	r.related[marvel.ComicsResource] = append(r.related[marvel.ComicsResource], comicIDs(st.Comics)...)
+	r.related[marvel.CharactersResource] = characterIDs(st.Characters)
+	r.related[marvel.CreatorsResource] = creatorIDs(st.Creators)
+	r.related[marvel.EventsResource] = eventIDs(st.Events)
+	r.related[marvel.SeriesResource] = seriesIDs(st.Series)
+	return r
+}
+
+// record returns the record of the entity of the resource with the given ID,
+// or nil if there is none. The store must be locked for reading.
+func (s *Store) record(resource marvel.Resource, id int) *record {
+	r := s.entityRecord(resource, id)
+	if r != nil {
+		r.position = s.positions[resource][id]
+	}
+	return r
+}
+
+// entityRecord returns the record of the entity of the resource with the given
+// ID, without its position, or nil if there is none.
+func (s *Store) entityRecord(resource marvel.Resource, id int) *record {
+	switch resource {
+	case marvel.CharactersResource:
+		if ch, ok := s.characters[id]; ok {
+			return characterRecord(ch)
+		}
+	case marvel.ComicsResource:
+		if co, ok := s.comics[id]; ok {
+			return comicRecord(co)
+		}
+	case marvel.CreatorsResource:
+		if ctr, ok := s.creators[id]; ok {
+			return creatorRecord(ctr)
+		}
+	case marvel.EventsResource:
+		if ev, ok := s.events[id]; ok {
+			return eventRecord(ev)
+		}
+	case marvel.SeriesResource:
+		if sr, ok := s.series[id]; ok {
+			return seriesRecord(sr)
+		}
+	case marvel.StoriesResource:
+		if st, ok := s.stories[id]; ok {
+			return storyRecord(st)
+		}
+	}
+	return nil
+}
+
+// records returns a record of every entity of the resource. The store must be
+// locked for reading.
+func (s *Store) records(resource marvel.Resource) []*record {
+	var records []*record
+	switch resource {
+	case marvel.CharactersResource:
+		for _, ch := range s.characters {
+			records = append(records, characterRecord(ch))
+		}
+	case marvel.ComicsResource:
+		for _, co := range s.comics {
+			records = append(records, comicRecord(co))
+		}
+	case marvel.CreatorsResource:
+		for _, ctr := range s.creators {
+			records = append(records, creatorRecord(ctr))
+		}
+	case marvel.EventsResource:
+		for _, ev := range s.events {
+			records = append(records, eventRecord(ev))
+		}
+	case marvel.SeriesResource:
+		for _, sr := range s.series {
+			records = append(records, seriesRecord(sr))
+		}
+	case marvel.StoriesResource:
+		for _, st := range s.stories {
+			records = append(records, storyRecord(st))
+		}
+	}
+	for _, r := range records {
+		r.position = s.positions[resource][r.id]
+	}
+	return records
+}
@@ -0,0 +1,134 @@
+package marveltest
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+
+	"github.com/dustinrc/marvel"
+)
+
+// Server is a fake Marvel API serving a Store over HTTP. Unlike a Client from
+// NewClient, it checks each request's authentication parameters exactly as the
+// API does, and can be made to fail requests.
+type Server struct {
+	*httptest.Server
+	Store *Store
+
+	// PublicKey and PrivateKey are the keys requests must be signed with.
+	PublicKey  string
+	PrivateKey string
+
+	mu       sync.Mutex
+	failures []int
+}
+
+// NewServer starts and returns a Server with an empty Store, which accepts
+// requests signed with PublicKey and PrivateKey. The caller should call Close
+// when finished, to shut it down.
+func NewServer() *Server {
+	srv := &Server{
+		Store:      NewStore(),
+		PublicKey:  PublicKey,
+		PrivateKey: PrivateKey,
+	}
+	srv.Server = httptest.NewServer(http.HandlerFunc(srv.serveHTTP))
+	return srv
+}
+
+// NewClient returns a marvel.Client which sends its requests to the server,
+// signed with the server's keys. Any options are passed on to marvel.NewClient.
+func (srv *Server) NewClient(opts ...marvel.Option) *marvel.Client {
+	opts = append([]marvel.Option{marvel.WithBaseURL(srv.URL)}, opts...)
+	return marvel.NewClient(marvel.NewServerSideAuth(srv.PublicKey, srv.PrivateKey), srv.Client(), opts...)
+}
+
+// Fail makes the next n requests fail with the API's reply for the given
+// status, e.g., 401 for invalid credentials, 409 for a rejected parameter, 429
+// when rate limited or 500 for an internal error.
+func (srv *Server) Fail(status, n int) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	for i := 0; i < n; i++ {
+		srv.failures = append(srv.failures, status)
+	}
+}
+
+// nextFailure returns the status with which to fail the next request, if any.
+func (srv *Server) nextFailure() (int, bool) {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if len(srv.failures) == 0 {
+		return 0, false
+	}
+	status := srv.failures[0]
+	srv.failures = srv.failures[1:]
+	return status, true
+}
+
+// serveHTTP fails the request if required to, or if it is not authenticated,
+// and otherwise serves it from the store.
+func (srv *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if status, ok := srv.nextFailure(); ok {
+		writeError(w, failure(status))
+		return
+	}
+	if apiErr := srv.authenticate(r.URL.Query()); apiErr != nil {
+		writeError(w, apiErr)
+		return
+	}
+	srv.Store.ServeHTTP(w, r)
+}
+
+// authenticate returns the error the API would give for the authentication
+// parameters, if any.
+func (srv *Server) authenticate(query url.Values) *apiError {
+	ts, apikey, hash := query.Get("ts"), query.Get("apikey"), query.Get("hash")
+	switch {
+	case apikey == "":
+		return missingParameter("You must provide a user key.")
+	case apikey != srv.PublicKey:
+		return invalidCredentials("The passed API key is invalid.")
+	case ts == "":
+		return missingParameter("You must provide a timestamp.")
+	case hash == "":
+		return missingParameter("You must provide a hash.")
+	}
+	sum := md5.Sum([]byte(ts + srv.PrivateKey + srv.PublicKey))
+	if hash != hex.EncodeToString(sum[:]) {
+		return invalidCredentials("That hash, timestamp and key combination is invalid.")
+	}
+	return nil
+}
+
+// missingParameter returns the error the API gives for a missing
+// authentication parameter.
+func missingParameter(message string) *apiError {
+	return &apiError{status: http.StatusConflict, Code: marvel.CodeMissingParameter, Message: message}
+}
+
+// invalidCredentials returns the error the API gives for invalid
+// authentication parameters.
+func invalidCredentials(message string) *apiError {
+	return &apiError{status: http.StatusUnauthorized, Code: marvel.CodeInvalidCredentials, Message: message}
+}
+
+// failure returns the error the API gives with the status.
+func failure(status int) *apiError {
+	switch status {
+	case http.StatusUnauthorized:
+		return invalidCredentials("The passed API key is invalid.")
+	case http.StatusConflict:
+		return conflict("You may not request more than %d items.", maxLimit)
+	case http.StatusTooManyRequests:
+		return &apiError{
+			status:  status,
+			Code:    marvel.CodeRequestThrottled,
+			Message: "You have exceeded your rate limit.  Please try again later.",
+		}
+	}
+	return &apiError{status: status, Code: status, Status: http.StatusText(status)}
+}
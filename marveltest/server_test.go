@@ -0,0 +1,133 @@
+package marveltest_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/dustinrc/marvel"
+	"github.com/dustinrc/marvel/marveltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer(t *testing.T) {
+	srv := marveltest.NewServer()
+	defer srv.Close()
+	srv.Store.AddCreators(marvel.Creator{ID: 30, FullName: "Stan Lee"})
+	for id := 1; id <= 150; id++ {
+		srv.Store.AddComics(marvel.Comic{
+			ID:       id,
+			Title:    fmt.Sprintf("Comic %d", id),
+			Creators: marvel.CreatorList{Items: []marvel.CreatorSummary{{Summary: summary("creators", 30)}}},
+		})
+	}
+	c := srv.NewClient()
+
+	creator, err := c.Creators.Get(30)
+	assert.NoError(t, err)
+	assert.Equal(t, "Stan Lee", creator.FullName)
+
+	wrap, _, err := c.Creators.ComicsWrapped(30, &marvel.ComicParams{Offset: 140})
+	assert.NoError(t, err)
+	assert.Equal(t, 140, wrap.Data.Offset)
+	assert.Equal(t, 20, wrap.Data.Limit)
+	assert.Equal(t, 150, wrap.Data.Total)
+	assert.Equal(t, 10, wrap.Data.Count)
+	assert.NotEmpty(t, wrap.ETag)
+	assert.NotEmpty(t, wrap.AttributionText)
+
+	count := 0
+	err = c.Comics.Walk(nil, func(marvel.Comic) error {
+		count++
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 150, count)
+}
+
+func TestServerAuth(t *testing.T) {
+	srv := marveltest.NewServer()
+	defer srv.Close()
+	srv.Store.AddCharacters(marvel.Character{ID: 1, Name: "Hulk"})
+
+	testCases := []struct {
+		desc   string
+		query  string
+		status int
+		code   string
+	}{
+		{"missing key", "ts=1&hash=abc", 409, "MissingParameter"},
+		{"unknown key", "ts=1&apikey=nope&hash=abc", 401, "InvalidCredentials"},
+		{"missing timestamp", "apikey=" + marveltest.PublicKey + "&hash=abc", 409, "MissingParameter"},
+		{"missing hash", "ts=1&apikey=" + marveltest.PublicKey, 409, "MissingParameter"},
+		{"invalid hash", "ts=1&apikey=" + marveltest.PublicKey + "&hash=abc", 401, "InvalidCredentials"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			resp, err := http.Get(srv.URL + "/v1/public/characters?" + tC.query)
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer resp.Body.Close()
+			body := map[string]interface{}{}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, tC.status, resp.StatusCode)
+			assert.Equal(t, tC.code, body["code"])
+		})
+	}
+
+	t.Run("client with the server's keys", func(t *testing.T) {
+		_, err := srv.NewClient().Characters.Get(1)
+		assert.NoError(t, err)
+	})
+	t.Run("client with other keys", func(t *testing.T) {
+		c := marvel.NewClient(marvel.NewServerSideAuth(marveltest.PublicKey, "wrong"), nil, marvel.WithBaseURL(srv.URL))
+		_, err := c.Characters.Get(1)
+		assert.True(t, errors.Is(err, marvel.ErrInvalidCredentials), "Unexpected error: %v", err)
+	})
+}
+
+func TestServerFail(t *testing.T) {
+	srv := marveltest.NewServer()
+	defer srv.Close()
+	srv.Store.AddEvents(marvel.Event{ID: 1, Title: "Secret Wars"})
+	c := srv.NewClient()
+	c.Retry(nil)
+
+	testCases := []struct {
+		status int
+		kind   error
+	}{
+		{http.StatusUnauthorized, marvel.ErrInvalidCredentials},
+		{http.StatusConflict, marvel.ErrInvalidParameter},
+		{http.StatusTooManyRequests, marvel.ErrRateLimited},
+		{http.StatusInternalServerError, nil},
+	}
+	for _, tC := range testCases {
+		t.Run(http.StatusText(tC.status), func(t *testing.T) {
+			srv.Fail(tC.status, 2)
+			for i := 0; i < 2; i++ {
+				_, err := c.Events.Get(1)
+				var apiErr *marvel.APIError
+				if assert.True(t, errors.As(err, &apiErr), "Unexpected error: %v", err) {
+					assert.Equal(t, tC.status, apiErr.StatusCode)
+				}
+				if tC.kind != nil {
+					assert.True(t, errors.Is(err, tC.kind), "Unexpected error: %v", err)
+				}
+			}
+			_, err := c.Events.Get(1)
+			assert.NoError(t, err, "Request failed after injected failures")
+		})
+	}
+
+	t.Run("retried", func(t *testing.T) {
+		c := srv.NewClient()
+		c.Retry(&marvel.RetryPolicy{MaxAttempts: 3, StatusCodes: []int{http.StatusInternalServerError}})
+		srv.Fail(http.StatusInternalServerError, 2)
+		_, err := c.Events.Get(1)
+		assert.NoError(t, err)
+	})
+}
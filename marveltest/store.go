@@ -0,0 +1,189 @@
+// Package marveltest provides an in-memory fake of the Marvel API, for testing
+// code which uses the marvel package without API keys or network access.
+//
+// A Store holds the fake API's entities, seeded from Go values or JSON
+// fixtures. NewClient returns a marvel.Client whose requests are served by a
+// Store in memory, honoring the API's filters, ordering, pagination and errors.
+package marveltest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/dustinrc/marvel"
+)
+
+// resourceURI returns the URI of the entity as given by the API.
+func resourceURI(resource marvel.Resource, id int) string {
+	return fmt.Sprintf("http://gateway.marvel.com/v1/public/%s/%d", resource, id)
+}
+
+// Fixture is the layout of a JSON fixture: the entities of each resource, as
+// given by the API.
+type Fixture struct {
+	Characters []marvel.Character `json:"characters,omitempty"`
+	Comics     []marvel.Comic     `json:"comics,omitempty"`
+	Creators   []marvel.Creator   `json:"creators,omitempty"`
+	Events     []marvel.Event     `json:"events,omitempty"`
+	Series     []marvel.Series    `json:"series,omitempty"`
+	Stories    []marvel.Story     `json:"stories,omitempty"`
+}
+
+// Store is the set of entities served by the fake API. It is safe for
+// concurrent use. Entities are related to each other through the summaries in
+// their lists, e.g., a character appears in a comic if either lists the other.
+// Listings give entities in the order they were first added, unless ordered
+// otherwise by the orderBy parameter.
+type Store struct {
+	mu         sync.RWMutex
+	positions  map[marvel.Resource]map[int]int
+	characters map[int]marvel.Character
+	comics     map[int]marvel.Comic
+	creators   map[int]marvel.Creator
+	events     map[int]marvel.Event
+	series     map[int]marvel.Series
+	stories    map[int]marvel.Story
+}
+
+// NewStore returns an empty Store.
+func NewStore() *Store {
+	return &Store{
+		positions:  make(map[marvel.Resource]map[int]int),
+		characters: make(map[int]marvel.Character),
+		comics:     make(map[int]marvel.Comic),
+		creators:   make(map[int]marvel.Creator),
+		events:     make(map[int]marvel.Event),
+		series:     make(map[int]marvel.Series),
+		stories:    make(map[int]marvel.Story),
+	}
+}
+
+// AddCharacters adds the characters to the store, replacing any with the same
+// ID. A missing ResourceURI is filled in.
+func (s *Store) AddCharacters(characters ...marvel.Character) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ch := range characters {
+		if ch.ResourceURI == "" {
+			ch.ResourceURI = resourceURI(marvel.CharactersResource, ch.ID)
+		}
+		s.place(marvel.CharactersResource, ch.ID)
+		s.characters[ch.ID] = ch
+	}
+}
+
+// AddComics adds the comics to the store, replacing any with the same ID. A
+// missing ResourceURI is filled in.
+func (s *Store) AddComics(comics ...marvel.Comic) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, co := range comics {
+		if co.ResourceURI == "" {
+			co.ResourceURI = resourceURI(marvel.ComicsResource, co.ID)
+		}
+		s.place(marvel.ComicsResource, co.ID)
+		s.comics[co.ID] = co
+	}
+}
+
+// AddCreators adds the creators to the store, replacing any with the same ID.
+// A missing ResourceURI is filled in.
+func (s *Store) AddCreators(creators ...marvel.Creator) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ctr := range creators {
+		if ctr.ResourceURI == "" {
+			ctr.ResourceURI = resourceURI(marvel.CreatorsResource, ctr.ID)
+		}
+		s.place(marvel.CreatorsResource, ctr.ID)
+		s.creators[ctr.ID] = ctr
+	}
+}
+
+// AddEvents adds the events to the store, replacing any with the same ID. A
+// missing ResourceURI is filled in.
+func (s *Store) AddEvents(events ...marvel.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ev := range events {
+		if ev.ResourceURI == "" {
+			ev.ResourceURI = resourceURI(marvel.EventsResource, ev.ID)
+		}
+		s.place(marvel.EventsResource, ev.ID)
+		s.events[ev.ID] = ev
+	}
+}
+
+// AddSeries adds the series to the store, replacing any with the same ID. A
+// missing ResourceURI is filled in.
+func (s *Store) AddSeries(series ...marvel.Series) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sr := range series {
+		if sr.ResourceURI == "" {
+			sr.ResourceURI = resourceURI(marvel.SeriesResource, sr.ID)
+		}
+		s.place(marvel.SeriesResource, sr.ID)
+		s.series[sr.ID] = sr
+	}
+}
+
+// AddStories adds the stories to the store, replacing any with the same ID. A
+// missing ResourceURI is filled in.
+func (s *Store) AddStories(stories ...marvel.Story) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, st := range stories {
+		if st.ResourceURI == "" {
+			st.ResourceURI = resourceURI(marvel.StoriesResource, st.ID)
+		}
+		s.place(marvel.StoriesResource, st.ID)
+		s.stories[st.ID] = st
+	}
+}
+
+// place records the position of the entity of the resource with the given ID
+// in unordered listings, if it has none yet. The store must be locked.
+func (s *Store) place(resource marvel.Resource, id int) {
+	positions, ok := s.positions[resource]
+	if !ok {
+		positions = make(map[int]int)
+		s.positions[resource] = positions
+	}
+	if _, ok := positions[id]; !ok {
+		positions[id] = len(positions)
+	}
+}
+
+// Load adds every entity of the fixture to the store.
+func (s *Store) Load(f *Fixture) {
+	s.AddCharacters(f.Characters...)
+	s.AddComics(f.Comics...)
+	s.AddCreators(f.Creators...)
+	s.AddEvents(f.Events...)
+	s.AddSeries(f.Series...)
+	s.AddStories(f.Stories...)
+}
+
+// LoadJSON decodes a Fixture from r and adds its entities to the store.
+func (s *Store) LoadJSON(r io.Reader) error {
+	f := &Fixture{}
+	if err := json.NewDecoder(r).Decode(f); err != nil {
+		return fmt.Errorf("marveltest: decoding fixture: %w", err)
+	}
+	s.Load(f)
+	return nil
+}
+
+// LoadFile adds the entities of the JSON fixture at path to the store.
+func (s *Store) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return s.LoadJSON(f)
+}
@@ -0,0 +1,298 @@
+package marveltest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dustinrc/marvel"
+	"github.com/dustinrc/marvel/marveltest"
+	"github.com/stretchr/testify/assert"
+)
+
+// summary returns a summary of the entity of the resource with the given ID.
+func summary(resource string, id int) marvel.Summary {
+	return marvel.Summary{ResourceURI: fmt.Sprintf("http://gateway.marvel.com/v1/public/%s/%d", resource, id)}
+}
+
+// date returns midnight UTC of the given day in 2016.
+func date(month time.Month, day int) marvel.Time {
+	return marvel.Time{Time: time.Date(2016, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+// newTestStore returns a store holding 250 characters, named "Character
+// <ID>", and a handful of related comics.
+func newTestStore() *marveltest.Store {
+	store := marveltest.NewStore()
+	for id := 1; id <= 250; id++ {
+		store.AddCharacters(marvel.Character{
+			ID:       id,
+			Name:     fmt.Sprintf("Character %03d", id),
+			Modified: date(time.January, id%28+1),
+		})
+	}
+	store.AddCharacters(marvel.Character{
+		ID:     1009610,
+		Name:   "Spider-Man",
+		Comics: marvel.ComicList{Items: []marvel.ComicSummary{{Summary: summary("comics", 1)}}},
+	})
+	store.AddComics(
+		marvel.Comic{
+			ID:     1,
+			Title:  "Amazing Fantasy #15",
+			Format: "comic",
+			Dates:  []marvel.ComicDate{{Type: "onsaleDate", Date: date(time.August, 17)}},
+		},
+		marvel.Comic{
+			ID:         2,
+			Title:      "Amazing Spider-Man #1",
+			Format:     "comic",
+			Dates:      []marvel.ComicDate{{Type: "onsaleDate", Date: date(time.September, 17)}},
+			Characters: marvel.CharacterList{Items: []marvel.CharacterSummary{{Summary: summary("characters", 1009610)}, {Summary: summary("characters", 1)}}},
+		},
+		marvel.Comic{
+			ID:                 3,
+			Title:              "Amazing Spider-Man #1",
+			Format:             "comic",
+			VariantDescription: "Variant",
+			Characters:         marvel.CharacterList{Items: []marvel.CharacterSummary{{Summary: summary("characters", 1009610)}}},
+		},
+		marvel.Comic{ID: 4, Title: "Spider-Man Omnibus", Format: "hardcover"},
+	)
+	return store
+}
+
+func TestClientGet(t *testing.T) {
+	c := marveltest.NewClient(newTestStore())
+
+	char, err := c.Characters.Get(1009610)
+	assert.NoError(t, err)
+	assert.Equal(t, "Spider-Man", char.Name)
+	assert.Equal(t, "http://gateway.marvel.com/v1/public/characters/1009610", char.ResourceURI)
+
+	_, err = c.Characters.Get(404)
+	assert.True(t, errors.Is(err, marvel.ErrNotFound), "Unexpected error: %v", err)
+}
+
+func TestClientList(t *testing.T) {
+	c := marveltest.NewClient(newTestStore())
+
+	testCases := []struct {
+		desc   string
+		params *marvel.CharacterParams
+		total  int
+		ids    []int
+	}{
+		{
+			desc:   "default page",
+			params: nil,
+			total:  251,
+			ids:    []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20},
+		},
+		{
+			desc:   "name prefix",
+			params: &marvel.CharacterParams{NameStartsWith: "character 24"},
+			total:  10,
+			ids:    []int{240, 241, 242, 243, 244, 245, 246, 247, 248, 249},
+		},
+		{
+			desc:   "exact name",
+			params: &marvel.CharacterParams{Name: "spider-man"},
+			total:  1,
+			ids:    []int{1009610},
+		},
+		{
+			desc:   "ordered and paged",
+			params: &marvel.CharacterParams{OrderBy: marvel.CharacterOrderByNameDesc, Offset: 1, Limit: 3},
+			total:  251,
+			ids:    []int{250, 249, 248},
+		},
+		{
+			desc:   "modified since",
+			params: &marvel.CharacterParams{ModifiedSince: date(time.January, 28).Time, Limit: 5},
+			total:  8,
+			ids:    []int{27, 55, 83, 111, 139},
+		},
+		{
+			desc:   "related comics",
+			params: &marvel.CharacterParams{Comics: marvel.IDList{2, 3}},
+			total:  2,
+			ids:    []int{1, 1009610},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			wrap, _, err := c.Characters.AllWrapped(tC.params)
+			assert.NoError(t, err)
+			assert.Equal(t, tC.total, wrap.Data.Total)
+			ids := []int{}
+			for _, char := range wrap.Data.Results {
+				ids = append(ids, char.ID)
+			}
+			assert.Equal(t, tC.ids, ids)
+		})
+	}
+}
+
+func TestClientListOrder(t *testing.T) {
+	store := marveltest.NewStore()
+	store.AddCharacters(
+		marvel.Character{ID: 3, Name: "Thor"},
+		marvel.Character{ID: 2, Name: "Hulk"},
+		marvel.Character{ID: 1, Name: "Hulk"},
+	)
+	store.AddCharacters(marvel.Character{ID: 1, Name: "Hulk (Bruce Banner)"})
+	c := marveltest.NewClient(store)
+
+	testCases := []struct {
+		desc   string
+		params *marvel.CharacterParams
+		ids    []int
+	}{
+		{"unordered in the order added", nil, []int{3, 2, 1}},
+		{"ties in the order added", &marvel.CharacterParams{NameStartsWith: "hulk", OrderBy: marvel.CharacterOrderByModified}, []int{2, 1}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			chars, err := c.Characters.All(tC.params)
+			assert.NoError(t, err)
+			ids := []int{}
+			for _, char := range chars {
+				ids = append(ids, char.ID)
+			}
+			assert.Equal(t, tC.ids, ids)
+		})
+	}
+}
+
+func TestClientComicFilters(t *testing.T) {
+	c := marveltest.NewClient(newTestStore())
+
+	testCases := []struct {
+		desc   string
+		params *marvel.ComicParams
+		ids    []int
+	}{
+		{"format", &marvel.ComicParams{Format: marvel.FormatHardcover}, []int{4}},
+		{"format type", &marvel.ComicParams{FormatType: marvel.FormatTypeComic}, []int{1, 2, 3}},
+		{"no variants", &marvel.ComicParams{NoVariants: true, TitleStartsWith: "amazing spider"}, []int{2}},
+		{"date range", &marvel.ComicParams{DateRange: marvel.DateRange{Start: date(time.August, 1).Time, End: date(time.August, 17).Time}}, []int{1}},
+		{"shared appearances", &marvel.ComicParams{SharedAppearances: marvel.IDList{1, 1009610}}, []int{2}},
+		{"ordered by title", &marvel.ComicParams{OrderBy: "-title,issueNumber"}, []int{4, 2, 3, 1}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			comics, err := c.Comics.All(tC.params)
+			assert.NoError(t, err)
+			ids := []int{}
+			for _, comic := range comics {
+				ids = append(ids, comic.ID)
+			}
+			assert.Equal(t, tC.ids, ids)
+		})
+	}
+}
+
+func TestClientSubresource(t *testing.T) {
+	c := marveltest.NewClient(newTestStore())
+
+	comics, err := c.Characters.Comics(1009610, nil)
+	assert.NoError(t, err)
+	assert.Len(t, comics, 3, "Comics related from either side should be listed")
+
+	chars, err := c.Comics.Characters(2, &marvel.CharacterParams{OrderBy: marvel.CharacterOrderByNameDesc})
+	assert.NoError(t, err)
+	if assert.Len(t, chars, 2) {
+		assert.Equal(t, "Spider-Man", chars[0].Name)
+	}
+
+	_, err = c.Characters.Comics(404, nil)
+	assert.True(t, errors.Is(err, marvel.ErrNotFound), "Unexpected error: %v", err)
+}
+
+func TestClientIterator(t *testing.T) {
+	c := marveltest.NewClient(newTestStore())
+
+	var api marvel.CharacterAPI = c.Characters
+	count := 0
+	err := api.Walk(&marvel.CharacterParams{NameStartsWith: "Character"}, func(marvel.Character) error {
+		count++
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 250, count)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = c.Characters.AllContext(ctx, nil)
+	assert.Error(t, err)
+}
+
+func TestStoreLoadJSON(t *testing.T) {
+	store := marveltest.NewStore()
+	err := store.LoadJSON(strings.NewReader(`{
+		"series": [{"id": 1, "title": "X-Men", "startYear": 1963, "type": "ongoing", "modified": "2014-04-29T14:18:17-0400"}],
+		"stories": [{"id": 7, "title": "Cover", "series": {"items": [{"resourceURI": "http://gateway.marvel.com/v1/public/series/1"}]}}]
+	}`))
+	assert.NoError(t, err)
+	c := marveltest.NewClient(store)
+
+	series, err := c.Series.Get(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "X-Men", series.Title)
+	assert.Equal(t, time.Date(2014, time.April, 29, 18, 18, 17, 0, time.UTC), series.Modified.UTC())
+
+	found, err := c.Series.All(&marvel.SeriesParams{SeriesType: marvel.SeriesTypeOngoing, StartYear: 1963})
+	assert.NoError(t, err)
+	assert.Len(t, found, 1)
+	stories, err := c.Series.Stories(1, nil)
+	assert.NoError(t, err)
+	assert.Len(t, stories, 1)
+
+	assert.Error(t, store.LoadJSON(strings.NewReader(`{"series": {}}`)))
+}
+
+func TestStoreErrors(t *testing.T) {
+	store := newTestStore()
+	testCases := []struct {
+		desc   string
+		method string
+		path   string
+		status int
+		code   interface{}
+	}{
+		{"unknown parameter", "GET", "/v1/public/characters?power=flight", 409, float64(409)},
+		{"blank parameter", "GET", "/v1/public/characters?name=", 409, float64(409)},
+		{"limit over maximum", "GET", "/v1/public/characters?limit=101", 409, float64(409)},
+		{"too many IDs", "GET", "/v1/public/characters?comics=1,2,3,4,5,6,7,8,9,10,11", 409, float64(409)},
+		{"invalid order", "GET", "/v1/public/comics?orderBy=name", 409, float64(409)},
+		{"unknown resource", "GET", "/v1/public/villains", 404, "ResourceNotFound"},
+		{"unknown subresource", "GET", "/v1/public/comics/1/series", 404, "ResourceNotFound"},
+		{"unknown entity", "GET", "/v1/public/events/1", 404, float64(404)},
+		{"method not allowed", "POST", "/v1/public/characters", 405, "MethodNotAllowed"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			store.ServeHTTP(rec, httptest.NewRequest(tC.method, tC.path, nil))
+			assert.Equal(t, tC.status, rec.Code)
+			body := map[string]interface{}{}
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tC.code, body["code"])
+		})
+	}
+
+	t.Run("errors reach the client", func(t *testing.T) {
+		c := marveltest.NewClient(store)
+		_, err := c.Events.Get(1)
+		var apiErr *marvel.APIError
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	})
+}
@@ -0,0 +1,130 @@
+package marvel
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Defaults of the parts of the base URL, which together make APIURL.
+const (
+	defaultGateway = "https://gateway.marvel.com"
+	defaultVersion = "v1"
+)
+
+// Option configures a Client. Options are given to NewClient.
+type Option func(*options)
+
+// options holds the configuration given by Options.
+type options struct {
+	gateway    string
+	version    string
+	httpClient *http.Client
+	userAgent  string
+	configure  []func(*Client)
+}
+
+// baseURL returns the URL under which the API's endpoints are requested.
+func (o *options) baseURL() string {
+	return trimVersionPath(o.gateway) + "/" + o.version + "/public/"
+}
+
+// trimVersionPath returns the gateway URL without any trailing slash or
+// version path, e.g., "/v1/public/".
+func trimVersionPath(gateway string) string {
+	gateway = strings.TrimSuffix(gateway, "/")
+	root := strings.TrimSuffix(gateway, "/public")
+	if root == gateway {
+		return gateway
+	}
+	i := strings.LastIndex(root, "/")
+	if i < 0 || !validVersion(root[i+1:]) {
+		return gateway
+	}
+	return root[:i]
+}
+
+// validVersion reports whether s names an API version, e.g., "v1".
+func validVersion(s string) bool {
+	if len(s) < 2 || s[0] != 'v' {
+		return false
+	}
+	for _, r := range s[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// WithBaseURL sets the root URL of the API gateway, e.g.,
+// "https://gateway.marvel.com", the default. Requests are sent under the
+// version's path beneath it, e.g., "/v1/public/characters". Use it to send
+// requests to a staging gateway, a caching proxy or a mock server. A complete
+// base URL, such as APIURL, is accepted too; its version path is replaced by
+// that of the version set with WithAPIVersion.
+func WithBaseURL(gateway string) Option {
+	return func(o *options) {
+		o.gateway = gateway
+	}
+}
+
+// WithAPIVersion sets the version of the API requested, e.g., "v1", the
+// default.
+func WithAPIVersion(version string) Option {
+	return func(o *options) {
+		o.version = version
+	}
+}
+
+// WithHTTPClient sets the http client used to send requests, in place of
+// NewClient's httpClient argument.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithRateLimit sets the Client's RateLimiter, as if by Client.RateLimit.
+func WithRateLimit(limiter RateLimiter) Option {
+	return func(o *options) {
+		o.configure = append(o.configure, func(c *Client) { c.RateLimit(limiter) })
+	}
+}
+
+// WithRetry sets the Client's RetryPolicy, as if by Client.Retry.
+func WithRetry(policy *RetryPolicy) Option {
+	return func(o *options) {
+		o.configure = append(o.configure, func(c *Client) { c.Retry(policy) })
+	}
+}
+
+// WithCache sets the Client's Cache, as if by Client.Cache.
+func WithCache(cache Cache, ttl time.Duration) Option {
+	return func(o *options) {
+		o.configure = append(o.configure, func(c *Client) { c.Cache(cache, ttl) })
+	}
+}
+
+// WithConditionalRequests turns ETag revalidation on or off, as if by
+// Client.ConditionalRequests.
+func WithConditionalRequests(enabled bool) Option {
+	return func(o *options) {
+		o.configure = append(o.configure, func(c *Client) { c.ConditionalRequests(enabled) })
+	}
+}
+
+// WithETagCapacity sets the number of responses remembered for conditional
+// requests, as if by Client.ETagCapacity.
+func WithETagCapacity(capacity int) Option {
+	return func(o *options) {
+		o.configure = append(o.configure, func(c *Client) { c.ETagCapacity(capacity) })
+	}
+}
@@ -0,0 +1,158 @@
+package marvel_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dustinrc/marvel"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingHandler serves a single character, recording the path and
+// User-Agent of each request.
+type recordingHandler struct {
+	paths      []string
+	userAgents []string
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (rh *recordingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rh.paths = append(rh.paths, r.URL.Path)
+	rh.userAgents = append(rh.userAgents, r.Header.Get("User-Agent"))
+	fmt.Fprint(w, `{"code": 200, "data": {"total": 1, "count": 1, "results": [{"id": 1}]}}`)
+}
+
+func TestNewClientOptions(t *testing.T) {
+	rh := &recordingHandler{}
+	srv := httptest.NewServer(rh)
+	defer srv.Close()
+
+	testCases := []struct {
+		desc      string
+		opts      []marvel.Option
+		path      string
+		userAgent string
+	}{
+		{
+			desc: "base URL",
+			opts: []marvel.Option{marvel.WithBaseURL(srv.URL)},
+			path: "/v1/public/characters/1",
+		},
+		{
+			desc: "base URL with a path",
+			opts: []marvel.Option{marvel.WithBaseURL(srv.URL + "/proxy/marvel/")},
+			path: "/proxy/marvel/v1/public/characters/1",
+		},
+		{
+			desc: "complete base URL",
+			opts: []marvel.Option{marvel.WithBaseURL(srv.URL + "/v1/public/")},
+			path: "/v1/public/characters/1",
+		},
+		{
+			desc: "complete base URL with a path",
+			opts: []marvel.Option{marvel.WithBaseURL(srv.URL + "/proxy/marvel/v1/public")},
+			path: "/proxy/marvel/v1/public/characters/1",
+		},
+		{
+			desc: "complete base URL of another version",
+			opts: []marvel.Option{marvel.WithBaseURL(srv.URL + "/v1/public/"), marvel.WithAPIVersion("v2")},
+			path: "/v2/public/characters/1",
+		},
+		{
+			desc: "path ending in public",
+			opts: []marvel.Option{marvel.WithBaseURL(srv.URL + "/public")},
+			path: "/public/v1/public/characters/1",
+		},
+		{
+			desc: "API version",
+			opts: []marvel.Option{marvel.WithBaseURL(srv.URL), marvel.WithAPIVersion("v2")},
+			path: "/v2/public/characters/1",
+		},
+		{
+			desc:      "user agent",
+			opts:      []marvel.Option{marvel.WithBaseURL(srv.URL), marvel.WithUserAgent("marvel-test/1.0")},
+			path:      "/v1/public/characters/1",
+			userAgent: "marvel-test/1.0",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			rh.paths, rh.userAgents = nil, nil
+			c := marvel.NewClient(&mockAuth{}, nil, tC.opts...)
+			_, err := c.Characters.Get(1)
+			assert.NoError(t, err)
+			assert.Equal(t, []string{tC.path}, rh.paths)
+			if tC.userAgent != "" {
+				assert.Equal(t, []string{tC.userAgent}, rh.userAgents)
+			}
+		})
+	}
+
+	t.Run("HTTP client", func(t *testing.T) {
+		rh.paths = nil
+		used := false
+		httpClient := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			used = true
+			return http.DefaultTransport.RoundTrip(req)
+		})}
+		c := marvel.NewClient(&mockAuth{}, http.DefaultClient, marvel.WithBaseURL(srv.URL), marvel.WithHTTPClient(httpClient))
+		_, err := c.Characters.Get(1)
+		assert.NoError(t, err)
+		assert.True(t, used, "WithHTTPClient's client was not used")
+	})
+	t.Run("exported API URL", func(t *testing.T) {
+		var urls []string
+		httpClient := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			u := *req.URL
+			u.RawQuery = ""
+			urls = append(urls, u.String())
+			srvURL, _ := url.Parse(srv.URL)
+			req.URL.Scheme, req.URL.Host = srvURL.Scheme, srvURL.Host
+			return http.DefaultTransport.RoundTrip(req)
+		})}
+		c := marvel.NewClient(&mockAuth{}, httpClient, marvel.WithBaseURL(marvel.APIURL))
+		_, err := c.Characters.Get(1)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{marvel.APIURL + "characters/1"}, urls)
+	})
+	t.Run("client settings", func(t *testing.T) {
+		rh.paths = nil
+		cache := marvel.NewMemoryCache(10)
+		c := marvel.NewClient(&mockAuth{}, nil, marvel.WithBaseURL(srv.URL), marvel.WithCache(cache, time.Hour),
+			marvel.WithRetry(nil), marvel.WithRateLimit(marvel.NewQuotaLimiter(0, 10)))
+		for i := 0; i < 2; i++ {
+			_, err := c.Characters.Get(1)
+			assert.NoError(t, err)
+		}
+		assert.Len(t, rh.paths, 1, "Cached response was not used")
+		assert.Equal(t, 1, c.CallsToday())
+
+		c.InvalidateCache(marvel.CharactersResource, 1)
+		_, err := c.Characters.Get(1)
+		assert.NoError(t, err)
+		assert.Len(t, rh.paths, 2, "Cache was not invalidated under the base URL")
+	})
+	t.Run("expanded lists", func(t *testing.T) {
+		rh.paths = nil
+		c := marvel.NewClient(&mockAuth{}, nil, marvel.WithBaseURL(srv.URL+"/proxy"))
+		_, err := c.ExpandCharacters(marvel.CharacterList{List: marvel.List{
+			Available:     21,
+			Returned:      20,
+			CollectionURI: "http://gateway.marvel.com/v1/public/comics/5/characters",
+		}})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"/proxy/v1/public/comics/5/characters"}, rh.paths)
+	})
+}
+
+// roundTripFunc is an http.RoundTripper implemented by a function.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip implements the http.RoundTripper interface.
+func (fn roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
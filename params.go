@@ -0,0 +1,184 @@
+package marvel
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxIDs is the most IDs the API accepts in a list filter.
+const maxIDs = 10
+
+// dateLayout is the layout of dates in queries. Times, e.g., ModifiedSince,
+// are sent in full, with their zone offset, like the API's own timestamps.
+const dateLayout = "2006-01-02"
+
+// DateRange is an inclusive range of dates, e.g., for ComicParams.DateRange.
+// Only the dates of Start and End are sent, e.g., "2013-01-01,2013-01-02".
+type DateRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// IsZero reports whether neither Start nor End is set.
+func (dr DateRange) IsZero() bool {
+	return dr.Start.IsZero() && dr.End.IsZero()
+}
+
+// String returns the range as it is sent to the API.
+func (dr DateRange) String() string {
+	return dr.Start.Format(dateLayout) + "," + dr.End.Format(dateLayout)
+}
+
+// EncodeValues implements the query.Encoder interface.
+func (dr DateRange) EncodeValues(key string, v *url.Values) error {
+	if !dr.IsZero() {
+		v.Set(key, dr.String())
+	}
+	return nil
+}
+
+// IDList is a list of entity IDs used to filter results, e.g.,
+// CharacterParams.Comics. It is sent comma-separated, e.g., "comics=1,2".
+type IDList []int
+
+// String returns the list as it is sent to the API.
+func (ids IDList) String() string {
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = strconv.Itoa(id)
+	}
+	return strings.Join(strs, ",")
+}
+
+// EncodeValues implements the query.Encoder interface.
+func (ids IDList) EncodeValues(key string, v *url.Values) error {
+	if len(ids) > 0 {
+		v.Set(key, ids.String())
+	}
+	return nil
+}
+
+// validator is implemented by the query parameter types, whose values are
+// checked before a request is sent with them.
+type validator interface {
+	Validate() error
+}
+
+// FieldError describes a query parameter the API would reject.
+type FieldError struct {
+	Field  string
+	Value  interface{}
+	Reason string
+}
+
+// Error implements the error interface.
+func (fe FieldError) Error() string {
+	return fmt.Sprintf("%s %v %s", fe.Field, fe.Value, fe.Reason)
+}
+
+// ValidationError lists every field of a parameter struct the API would
+// reject. It satisfies errors.Is(err, ErrInvalidParameter), like the API's own
+// rejections.
+type ValidationError struct {
+	Params string
+	Fields []FieldError
+}
+
+// Error implements the error interface.
+func (ve *ValidationError) Error() string {
+	reasons := make([]string, len(ve.Fields))
+	for i, fe := range ve.Fields {
+		reasons[i] = fe.Error()
+	}
+	return fmt.Sprintf("marvel: invalid %s: %s", ve.Params, strings.Join(reasons, "; "))
+}
+
+// Is reports whether the error is ErrInvalidParameter.
+func (ve *ValidationError) Is(target error) bool {
+	return target == ErrInvalidParameter
+}
+
+// validation collects the invalid fields of a parameter struct.
+type validation struct {
+	ValidationError
+}
+
+// newValidation returns a validation of the named parameter struct.
+func newValidation(params string) *validation {
+	return &validation{ValidationError{Params: params}}
+}
+
+// check records the field as invalid for the reason, unless ok.
+func (v *validation) check(ok bool, field string, value interface{}, reason string) {
+	if !ok {
+		v.Fields = append(v.Fields, FieldError{Field: field, Value: value, Reason: reason})
+	}
+}
+
+// enum checks that the field holds an accepted value.
+func (v *validation) enum(ok bool, field string, value string) {
+	v.check(ok, field, fmt.Sprintf("%q", value), "is not an accepted value")
+}
+
+// text checks that the string filter is not blank. Empty filters are omitted
+// from the query, but the API rejects those made only of spaces.
+func (v *validation) text(field, value string) {
+	v.check(value == "" || strings.TrimSpace(value) != "", field, fmt.Sprintf("%q", value), "is blank")
+}
+
+// ids checks that the list filter holds no more IDs than the API accepts.
+func (v *validation) ids(field string, ids IDList) {
+	v.check(len(ids) <= maxIDs, field, ids, fmt.Sprintf("has more than %d IDs", maxIDs))
+}
+
+// dateRange checks that the range, if given, has both a start and end date,
+// in order.
+func (v *validation) dateRange(field string, dr DateRange) {
+	ok := dr.IsZero() || !dr.Start.IsZero() && !dr.End.IsZero() && !dr.End.Before(dr.Start)
+	v.check(ok, field, dr, "is not a start and end date in order")
+}
+
+// page checks the paging parameters.
+func (v *validation) page(limit, offset int) {
+	v.check(limit >= 0, "Limit", limit, "is negative")
+	v.check(limit <= maxLimit, "Limit", limit, fmt.Sprintf("is over %d", maxLimit))
+	v.check(offset >= 0, "Offset", offset, "is negative")
+}
+
+// err returns the ValidationError, if any fields were invalid.
+func (v *validation) err() error {
+	if len(v.Fields) == 0 {
+		return nil
+	}
+	return &v.ValidationError
+}
+
+// oneOf reports whether value is empty or one of allowed.
+func oneOf(value string, allowed ...string) bool {
+	if value == "" {
+		return true
+	}
+	for _, a := range allowed {
+		if value == a {
+			return true
+		}
+	}
+	return false
+}
+
+// validOrder reports whether value is a comma-separated list of the given
+// fields, each optionally prefixed by "-" to sort in descending order.
+func validOrder(value string, fields ...string) bool {
+	if value == "" {
+		return true
+	}
+	for _, v := range strings.Split(value, ",") {
+		if v == "" || v == "-" || !oneOf(strings.TrimPrefix(v, "-"), fields...) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,303 @@
+package marvel_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dustinrc/marvel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParamsValidate(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		params interface{ Validate() error }
+		valid  bool
+	}{
+		{desc: "nil params", params: (*marvel.ComicParams)(nil), valid: true},
+		{desc: "empty params", params: &marvel.ComicParams{}, valid: true},
+		{desc: "character order", params: &marvel.CharacterParams{OrderBy: marvel.CharacterOrderByNameDesc}, valid: true},
+		{desc: "multiple orders", params: &marvel.CharacterParams{OrderBy: "name,-modified"}, valid: true},
+		{desc: "unknown order", params: &marvel.CharacterParams{OrderBy: "superpower"}},
+		{desc: "empty order in list", params: &marvel.CharacterParams{OrderBy: "name,"}},
+		{desc: "bare descending order", params: &marvel.CharacterParams{OrderBy: "-"}},
+		{desc: "another resource's order", params: &marvel.CharacterParams{OrderBy: "title"}},
+		{desc: "comic order", params: &marvel.ComicParams{OrderBy: marvel.ComicOrderByOnSaleDateDesc}, valid: true},
+		{desc: "comic format", params: &marvel.ComicParams{Format: marvel.FormatTradePaperback}, valid: true},
+		{desc: "unknown comic format", params: &marvel.ComicParams{Format: "paperback"}},
+		{desc: "comic format type", params: &marvel.ComicParams{FormatType: marvel.FormatTypeCollection}, valid: true},
+		{desc: "unknown comic format type", params: &marvel.ComicParams{FormatType: "graphic novel"}},
+		{desc: "date descriptor", params: &marvel.ComicParams{DateDescriptor: marvel.DateDescriptorThisWeek}, valid: true},
+		{desc: "unknown date descriptor", params: &marvel.ComicParams{DateDescriptor: "this week"}},
+		{desc: "creator order", params: &marvel.CreatorParams{OrderBy: marvel.CreatorOrderByLastName}, valid: true},
+		{desc: "unknown creator order", params: &marvel.CreatorParams{OrderBy: "name"}},
+		{desc: "event order", params: &marvel.EventParams{OrderBy: marvel.EventOrderByStartDateDesc}, valid: true},
+		{desc: "unknown event order", params: &marvel.EventParams{OrderBy: "endDate"}},
+		{desc: "series type", params: &marvel.SeriesParams{SeriesType: marvel.SeriesTypeOneShot}, valid: true},
+		{desc: "unknown series type", params: &marvel.SeriesParams{SeriesType: "one-shot"}},
+		{desc: "series contains", params: &marvel.SeriesParams{Contains: marvel.FormatList{marvel.FormatComic, marvel.FormatDigest}}, valid: true},
+		{desc: "unknown series contains", params: &marvel.SeriesParams{Contains: marvel.FormatList{marvel.FormatComic, "pamphlet"}}},
+		{desc: "series order", params: &marvel.SeriesParams{OrderBy: marvel.SeriesOrderByStartYear}, valid: true},
+		{desc: "unknown series order", params: &marvel.SeriesParams{OrderBy: "startDate"}},
+		{desc: "story order", params: &marvel.StoryParams{OrderBy: marvel.StoryOrderByIDDesc}, valid: true},
+		{desc: "unknown story order", params: &marvel.StoryParams{OrderBy: "title"}},
+		{desc: "maximum limit", params: &marvel.EventParams{Limit: 100}, valid: true},
+		{desc: "limit over maximum", params: &marvel.EventParams{Limit: 101}},
+		{desc: "negative limit", params: &marvel.StoryParams{Limit: -1}},
+		{desc: "negative offset", params: &marvel.SeriesParams{Offset: -20}},
+		{desc: "blank filter", params: &marvel.CreatorParams{LastNameStartsWith: "  "}},
+		{desc: "ten IDs", params: &marvel.CharacterParams{Comics: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}}, valid: true},
+		{desc: "more than ten IDs", params: &marvel.CharacterParams{Comics: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}}},
+		{desc: "date range", params: &marvel.ComicParams{DateRange: marvel.DateRange{Start: time.Now(), End: time.Now()}}, valid: true},
+		{desc: "date range without end", params: &marvel.ComicParams{DateRange: marvel.DateRange{Start: time.Now()}}},
+		{desc: "date range out of order", params: &marvel.ComicParams{DateRange: marvel.DateRange{Start: time.Now(), End: time.Now().AddDate(0, 0, -1)}}},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			err := tC.params.Validate()
+			if tC.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, marvel.ErrInvalidParameter), "Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	params := &marvel.ComicParams{
+		Format:     "paperback",
+		Title:      " ",
+		Characters: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+		Limit:      200,
+	}
+	err := params.Validate()
+	var ve *marvel.ValidationError
+	assert.True(t, errors.As(err, &ve))
+	assert.Equal(t, "ComicParams", ve.Params)
+	fields := []string{}
+	for _, fe := range ve.Fields {
+		fields = append(fields, fe.Field)
+	}
+	assert.Equal(t, []string{"Format", "Title", "Characters", "Limit"}, fields)
+	assert.Equal(t, `marvel: invalid ComicParams: Format "paperback" is not an accepted value; `+
+		`Title " " is blank; Characters 1,2,3,4,5,6,7,8,9,10,11 has more than 10 IDs; Limit 200 is over 100`,
+		err.Error())
+}
+
+func TestParamsValidatedBeforeRequest(t *testing.T) {
+	requests := 0
+	c := newServerClient(t, &mockAuth{},
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+		}))
+
+	_, err := c.Comics.All(&marvel.ComicParams{Format: "paperback"})
+	assert.True(t, errors.Is(err, marvel.ErrInvalidParameter))
+	_, err = c.Characters.Comics(1009610, &marvel.ComicParams{DateDescriptor: "today"})
+	assert.True(t, errors.Is(err, marvel.ErrInvalidParameter))
+	_, _, err = c.Events.AllWrapped(&marvel.EventParams{Limit: 1000})
+	assert.True(t, errors.Is(err, marvel.ErrInvalidParameter))
+	err = c.Series.AllIter(&marvel.SeriesParams{SeriesType: "endless"}).Walk(func(marvel.Series) error {
+		return nil
+	})
+	assert.True(t, errors.Is(err, marvel.ErrInvalidParameter))
+	assert.Zero(t, requests, "Request sent with invalid parameters")
+}
+
+func TestParamsQuery(t *testing.T) {
+	modified := time.Date(2014, time.April, 29, 14, 18, 17, 0, time.FixedZone("EDT", -4*60*60))
+	start := time.Date(2013, time.January, 1, 10, 0, 0, 0, time.UTC)
+	end := time.Date(2013, time.January, 2, 23, 59, 0, 0, time.UTC)
+
+	var query string
+	c := newServerClient(t, &mockAuth{},
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			values := r.URL.Query()
+			for _, key := range []string{"ts", "apikey", "hash"} {
+				values.Del(key)
+			}
+			query = values.Encode()
+			fmt.Fprint(w, `{"code": 200, "data": {"results": []}}`)
+		}))
+
+	testCases := []struct {
+		desc     string
+		send     func() error
+		expected string
+	}{
+		{
+			desc: "nil params",
+			send: func() error {
+				_, err := c.Comics.All(nil)
+				return err
+			},
+			expected: "",
+		},
+		{
+			desc: "character params",
+			send: func() error {
+				_, err := c.Characters.All(&marvel.CharacterParams{
+					Name:           "Spider-Man",
+					NameStartsWith: "Spi",
+					ModifiedSince:  modified,
+					Comics:         []int{1, 2},
+					Series:         []int{3},
+					Events:         []int{4},
+					Stories:        []int{5},
+					OrderBy:        marvel.CharacterOrderByNameDesc,
+					Limit:          10,
+					Offset:         20,
+				})
+				return err
+			},
+			expected: "comics=1%2C2&events=4&limit=10&modifiedSince=2014-04-29T14%3A18%3A17-0400&" +
+				"name=Spider-Man&nameStartsWith=Spi&offset=20&orderBy=-name&series=3&stories=5",
+		},
+		{
+			desc: "comic params",
+			send: func() error {
+				_, err := c.Comics.All(&marvel.ComicParams{
+					Format:            marvel.FormatTradePaperback,
+					FormatType:        marvel.FormatTypeCollection,
+					NoVariants:        true,
+					DateRange:         marvel.DateRange{Start: start, End: end},
+					Title:             "Avengers",
+					TitleStartsWith:   "Aven",
+					StartYear:         2013,
+					IssueNumber:       7,
+					DiamondCode:       "JUL130626",
+					DigitalID:         31342,
+					UPC:               "75960607938500711",
+					ISBN:              "978-0-7851-8493-7",
+					EAN:               "9780785184937",
+					ISSN:              "2470-1688",
+					HasDigitalIssue:   true,
+					ModifiedSince:     modified,
+					Creators:          []int{1, 8, 9},
+					Characters:        []int{2},
+					Series:            []int{3},
+					Events:            []int{4},
+					Stories:           []int{5},
+					SharedAppearances: []int{6},
+					Collaborators:     []int{7},
+					OrderBy:           "title,-onsaleDate",
+					Limit:             100,
+				})
+				return err
+			},
+			expected: "characters=2&collaborators=7&creators=1%2C8%2C9&dateRange=2013-01-01%2C2013-01-02&" +
+				"diamondCode=JUL130626&digitalId=31342&ean=9780785184937&events=4&format=trade+paperback&" +
+				"formatType=collection&hasDigitalIssue=true&isbn=978-0-7851-8493-7&issn=2470-1688&" +
+				"issueNumber=7&limit=100&modifiedSince=2014-04-29T14%3A18%3A17-0400&noVariants=true&" +
+				"orderBy=title%2C-onsaleDate&series=3&sharedAppearances=6&startYear=2013&stories=5&" +
+				"title=Avengers&titleStartsWith=Aven&upc=75960607938500711",
+		},
+		{
+			desc: "comic date descriptor",
+			send: func() error {
+				_, err := c.Comics.All(&marvel.ComicParams{DateDescriptor: marvel.DateDescriptorThisMonth})
+				return err
+			},
+			expected: "dateDescriptor=thisMonth",
+		},
+		{
+			desc: "creator params",
+			send: func() error {
+				_, err := c.Creators.All(&marvel.CreatorParams{
+					FirstName:            "Stan",
+					MiddleName:           "M",
+					LastName:             "Lee",
+					Suffix:               "Sr.",
+					NameStartsWith:       "St",
+					FirstNameStartsWith:  "S",
+					MiddleNameStartsWith: "M",
+					LastNameStartsWith:   "L",
+					ModifiedSince:        modified.UTC(),
+					Comics:               []int{1},
+					Series:               []int{2},
+					Events:               []int{3},
+					Stories:              []int{4, 40},
+					OrderBy:              marvel.CreatorOrderByLastName,
+					Limit:                5,
+				})
+				return err
+			},
+			expected: "comics=1&events=3&firstName=Stan&firstNameStartsWith=S&lastName=Lee&lastNameStartsWith=L&" +
+				"limit=5&middleName=M&middleNameStartsWith=M&modifiedSince=2014-04-29T18%3A18%3A17%2B0000&" +
+				"nameStartsWith=St&orderBy=lastName&series=2&stories=4%2C40&suffix=Sr.",
+		},
+		{
+			desc: "event params",
+			send: func() error {
+				_, err := c.Events.All(&marvel.EventParams{
+					Name:           "Civil War",
+					NameStartsWith: "Civ",
+					ModifiedSince:  modified,
+					Creators:       []int{1},
+					Characters:     []int{2, 3},
+					Series:         []int{3},
+					Comics:         []int{4},
+					Stories:        []int{5},
+					OrderBy:        marvel.EventOrderByStartDate,
+					Offset:         40,
+				})
+				return err
+			},
+			expected: "characters=2%2C3&comics=4&creators=1&modifiedSince=2014-04-29T14%3A18%3A17-0400&" +
+				"name=Civil+War&nameStartsWith=Civ&offset=40&orderBy=startDate&series=3&stories=5",
+		},
+		{
+			desc: "series params",
+			send: func() error {
+				_, err := c.Series.All(&marvel.SeriesParams{
+					Title:           "X-Men",
+					TitleStartsWith: "X",
+					StartYear:       1963,
+					ModifiedSince:   modified,
+					Comics:          []int{1},
+					Stories:         []int{2},
+					Events:          []int{3, 30},
+					Creators:        []int{4},
+					Characters:      []int{5},
+					SeriesType:      marvel.SeriesTypeOneShot,
+					Contains:        marvel.FormatList{marvel.FormatComic, marvel.FormatDigest},
+					OrderBy:         marvel.SeriesOrderByStartYearDesc,
+				})
+				return err
+			},
+			expected: "characters=5&comics=1&contains=comic%2Cdigest&creators=4&events=3%2C30&" +
+				"modifiedSince=2014-04-29T14%3A18%3A17-0400&orderBy=-startYear&seriesType=one+shot&" +
+				"startYear=1963&stories=2&title=X-Men&titleStartsWith=X",
+		},
+		{
+			desc: "story params",
+			send: func() error {
+				_, err := c.Stories.All(&marvel.StoryParams{
+					ModifiedSince: modified,
+					Comics:        []int{1},
+					Series:        []int{2, 20},
+					Events:        []int{3},
+					Creators:      []int{4},
+					Characters:    []int{5},
+					OrderBy:       marvel.StoryOrderByModifiedDesc,
+					Limit:         1,
+					Offset:        1,
+				})
+				return err
+			},
+			expected: "characters=5&comics=1&creators=4&events=3&limit=1&" +
+				"modifiedSince=2014-04-29T14%3A18%3A17-0400&offset=1&orderBy=-modified&series=2%2C20",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			query = "unsent"
+			assert.NoError(t, tC.send())
+			assert.Equal(t, tC.expected, query)
+		})
+	}
+}
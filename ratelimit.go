@@ -0,0 +1,192 @@
+package marvel
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrQuotaExceeded is returned by a QuotaLimiter when the daily call quota has
+// been used up.
+var ErrQuotaExceeded = errors.New("marvel: daily call quota exceeded")
+
+// RateLimiter is the interface for throttling the requests sent by a Client.
+// Wait blocks until a request may be sent, or returns an error if it may not.
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
+// QuotaLimiter is a RateLimiter which combines a token bucket, refilled at a
+// steady rate per second, with a budget of calls per day. Days begin at
+// midnight UTC.
+type QuotaLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	perDay int
+	day    time.Time
+	calls  int
+	now    func() time.Time
+}
+
+// NewQuotaLimiter returns a QuotaLimiter allowing perSecond requests each second
+// and perDay requests each day. A value of zero disables the respective limit.
+func NewQuotaLimiter(perSecond float64, perDay int) *QuotaLimiter {
+	burst := math.Max(1, math.Ceil(perSecond))
+	return &QuotaLimiter{
+		rate:   perSecond,
+		burst:  burst,
+		tokens: burst,
+		perDay: perDay,
+		now:    time.Now,
+	}
+}
+
+// Wait implements the RateLimiter interface. ErrQuotaExceeded is returned
+// immediately once the daily budget is spent, rather than waiting for the
+// following day.
+func (ql *QuotaLimiter) Wait(ctx context.Context) error {
+	ql.mu.Lock()
+	now := ql.now()
+	ql.rollover(now)
+	if ql.perDay > 0 && ql.calls >= ql.perDay {
+		ql.mu.Unlock()
+		return ErrQuotaExceeded
+	}
+	var delay time.Duration
+	if ql.rate > 0 {
+		ql.refill(now)
+		ql.tokens--
+		if ql.tokens < 0 {
+			delay = time.Duration(-ql.tokens / ql.rate * float64(time.Second))
+		}
+	}
+	ql.calls++
+	ql.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		ql.mu.Lock()
+		if ql.rate > 0 {
+			// The bucket may have refilled while waiting, so the refund
+			// must not take it over the burst.
+			ql.refill(ql.now())
+			ql.tokens = math.Min(ql.burst, ql.tokens+1)
+		}
+		ql.calls--
+		ql.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// refill adds the tokens accrued since the last refill, up to the burst. The
+// caller must hold ql.mu.
+func (ql *QuotaLimiter) refill(now time.Time) {
+	if !ql.last.IsZero() {
+		ql.tokens = math.Min(ql.burst, ql.tokens+now.Sub(ql.last).Seconds()*ql.rate)
+	}
+	ql.last = now
+}
+
+// CallsToday returns the number of calls permitted so far today.
+func (ql *QuotaLimiter) CallsToday() int {
+	ql.mu.Lock()
+	defer ql.mu.Unlock()
+	ql.rollover(ql.now())
+	return ql.calls
+}
+
+// Remaining returns the number of calls left in today's budget, or -1 if there
+// is no daily limit.
+func (ql *QuotaLimiter) Remaining() int {
+	if ql.perDay <= 0 {
+		return -1
+	}
+	return ql.perDay - ql.CallsToday()
+}
+
+// rollover resets the daily call count once now falls on a new day. The caller
+// must hold ql.mu.
+func (ql *QuotaLimiter) rollover(now time.Time) {
+	if day := startOfDay(now); !day.Equal(ql.day) {
+		ql.day = day
+		ql.calls = 0
+	}
+}
+
+// startOfDay returns midnight UTC of the day t falls on.
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// limitTransport is an http.RoundTripper which waits on a RateLimiter, if any,
+// before each request and counts the requests sent each day.
+type limitTransport struct {
+	mu      sync.Mutex
+	limiter RateLimiter
+	day     time.Time
+	calls   int
+	base    http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (lt *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	lt.mu.Lock()
+	limiter := lt.limiter
+	lt.mu.Unlock()
+	if limiter != nil {
+		if err := limiter.Wait(req.Context()); err != nil {
+			return nil, &limiterError{err}
+		}
+	}
+
+	lt.mu.Lock()
+	if day := startOfDay(time.Now()); !day.Equal(lt.day) {
+		lt.day = day
+		lt.calls = 0
+	}
+	lt.calls++
+	lt.mu.Unlock()
+
+	return lt.base.RoundTrip(req)
+}
+
+// limiterError is an error from a RateLimiter, for which the request was not
+// sent. It is never retried: waiting out a backoff cannot restore a spent quota.
+type limiterError struct {
+	err error
+}
+
+func (le *limiterError) Error() string { return le.err.Error() }
+
+func (le *limiterError) Unwrap() error { return le.err }
+
+// setLimiter replaces the RateLimiter consulted before each request.
+func (lt *limitTransport) setLimiter(limiter RateLimiter) {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+	lt.limiter = limiter
+}
+
+// callsToday returns the number of requests sent so far today.
+func (lt *limitTransport) callsToday() int {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+	if !startOfDay(time.Now()).Equal(lt.day) {
+		return 0
+	}
+	return lt.calls
+}
@@ -0,0 +1,95 @@
+package marvel_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dustinrc/marvel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuotaLimiterDaily(t *testing.T) {
+	ql := marvel.NewQuotaLimiter(0, 3)
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, ql.Wait(context.Background()))
+	}
+	assert.Equal(t, 3, ql.CallsToday())
+	assert.Equal(t, 0, ql.Remaining())
+	assert.Equal(t, marvel.ErrQuotaExceeded, ql.Wait(context.Background()))
+	assert.Equal(t, 3, ql.CallsToday(), "Rejected call should not be counted")
+}
+
+func TestQuotaLimiterPerSecond(t *testing.T) {
+	ql := marvel.NewQuotaLimiter(50, 0)
+	assert.Equal(t, -1, ql.Remaining())
+
+	start := time.Now()
+	for i := 0; i < 60; i++ {
+		assert.NoError(t, ql.Wait(context.Background()))
+	}
+	elapsed := time.Since(start)
+
+	// The first 50 calls use the initial burst; the next 10 wait 20ms each.
+	assert.True(t, elapsed >= 180*time.Millisecond, "Calls were not throttled: %v", elapsed)
+	assert.True(t, elapsed < time.Second, "Calls were throttled too much: %v", elapsed)
+}
+
+func TestQuotaLimiterContext(t *testing.T) {
+	ql := marvel.NewQuotaLimiter(1, 0)
+	assert.NoError(t, ql.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, ql.Wait(ctx))
+	assert.Equal(t, 1, ql.CallsToday(), "Cancelled call should not be counted")
+}
+
+func TestQuotaLimiterCancelAfterRefill(t *testing.T) {
+	ql := marvel.NewQuotaLimiter(20, 0)
+	for i := 0; i < 20; i++ {
+		assert.NoError(t, ql.Wait(context.Background()))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 40*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, ql.Wait(ctx))
+
+	// Once refilled, the bucket holds the burst of 20 calls, and no more.
+	time.Sleep(1100 * time.Millisecond)
+	start := time.Now()
+	for i := 0; i < 20; i++ {
+		assert.NoError(t, ql.Wait(context.Background()))
+	}
+	assert.True(t, time.Since(start) < 25*time.Millisecond, "Burst was throttled: %v", time.Since(start))
+	start = time.Now()
+	assert.NoError(t, ql.Wait(context.Background()))
+	assert.True(t, time.Since(start) >= 25*time.Millisecond, "Refund exceeded the burst")
+}
+
+func TestClientRateLimit(t *testing.T) {
+	c := newServerClient(t, &mockAuth{},
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, `{"code": 200, "data": {"results": [{"id": 1}]}}`)
+		}))
+	c.RateLimit(marvel.NewQuotaLimiter(0, 2))
+
+	assert.Equal(t, 0, c.CallsToday())
+	_, err := c.Comics.Get(1)
+	assert.NoError(t, err)
+	_, err = c.Stories.Get(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, c.CallsToday())
+
+	_, err = c.Characters.Get(1)
+	assert.True(t, errors.Is(err, marvel.ErrQuotaExceeded), "Expected quota error, got %v", err)
+	assert.Equal(t, 2, c.CallsToday())
+
+	c.RateLimit(nil)
+	_, err = c.Characters.Get(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, c.CallsToday())
+}
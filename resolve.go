@@ -0,0 +1,89 @@
+package marvel
+
+import (
+	"context"
+)
+
+// ResolveCharacter returns the full character described by the summary.
+func (c *Client) ResolveCharacter(summary CharacterSummary) (*Character, error) {
+	return c.ResolveCharacterContext(context.Background(), summary)
+}
+
+// ResolveCharacterContext is like ResolveCharacter, but the request is sent using ctx.
+func (c *Client) ResolveCharacterContext(ctx context.Context, summary CharacterSummary) (*Character, error) {
+	id, err := summary.ID()
+	if err != nil {
+		return nil, err
+	}
+	return c.Characters.GetContext(ctx, id)
+}
+
+// ResolveComic returns the full comic described by the summary.
+func (c *Client) ResolveComic(summary ComicSummary) (*Comic, error) {
+	return c.ResolveComicContext(context.Background(), summary)
+}
+
+// ResolveComicContext is like ResolveComic, but the request is sent using ctx.
+func (c *Client) ResolveComicContext(ctx context.Context, summary ComicSummary) (*Comic, error) {
+	id, err := summary.ID()
+	if err != nil {
+		return nil, err
+	}
+	return c.Comics.GetContext(ctx, id)
+}
+
+// ResolveCreator returns the full creator described by the summary.
+func (c *Client) ResolveCreator(summary CreatorSummary) (*Creator, error) {
+	return c.ResolveCreatorContext(context.Background(), summary)
+}
+
+// ResolveCreatorContext is like ResolveCreator, but the request is sent using ctx.
+func (c *Client) ResolveCreatorContext(ctx context.Context, summary CreatorSummary) (*Creator, error) {
+	id, err := summary.ID()
+	if err != nil {
+		return nil, err
+	}
+	return c.Creators.GetContext(ctx, id)
+}
+
+// ResolveEvent returns the full event described by the summary.
+func (c *Client) ResolveEvent(summary EventSummary) (*Event, error) {
+	return c.ResolveEventContext(context.Background(), summary)
+}
+
+// ResolveEventContext is like ResolveEvent, but the request is sent using ctx.
+func (c *Client) ResolveEventContext(ctx context.Context, summary EventSummary) (*Event, error) {
+	id, err := summary.ID()
+	if err != nil {
+		return nil, err
+	}
+	return c.Events.GetContext(ctx, id)
+}
+
+// ResolveSeries returns the full series described by the summary.
+func (c *Client) ResolveSeries(summary SeriesSummary) (*Series, error) {
+	return c.ResolveSeriesContext(context.Background(), summary)
+}
+
+// ResolveSeriesContext is like ResolveSeries, but the request is sent using ctx.
+func (c *Client) ResolveSeriesContext(ctx context.Context, summary SeriesSummary) (*Series, error) {
+	id, err := summary.ID()
+	if err != nil {
+		return nil, err
+	}
+	return c.Series.GetContext(ctx, id)
+}
+
+// ResolveStory returns the full story described by the summary.
+func (c *Client) ResolveStory(summary StorySummary) (*Story, error) {
+	return c.ResolveStoryContext(context.Background(), summary)
+}
+
+// ResolveStoryContext is like ResolveStory, but the request is sent using ctx.
+func (c *Client) ResolveStoryContext(ctx context.Context, summary StorySummary) (*Story, error) {
+	id, err := summary.ID()
+	if err != nil {
+		return nil, err
+	}
+	return c.Stories.GetContext(ctx, id)
+}
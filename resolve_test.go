@@ -0,0 +1,82 @@
+package marvel_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dustinrc/marvel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummaryID(t *testing.T) {
+	testCases := []struct {
+		desc string
+		uri  string
+		id   int
+		err  bool
+	}{
+		{desc: "character", uri: "http://gateway.marvel.com/v1/public/characters/1009610", id: 1009610},
+		{desc: "comic", uri: "http://gateway.marvel.com/v1/public/comics/21366", id: 21366},
+		{desc: "no ID", uri: "http://gateway.marvel.com/v1/public/comics", err: true},
+		{desc: "trailing slash", uri: "http://gateway.marvel.com/v1/public/comics/21366/", err: true},
+		{desc: "empty", uri: "", err: true},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			id, err := marvel.Summary{ResourceURI: tC.uri}.ID()
+			if tC.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tC.id, id)
+		})
+	}
+}
+
+func TestClientResolve(t *testing.T) {
+	var paths []string
+	c := newServerClient(t, &mockAuth{},
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			paths = append(paths, r.URL.Path)
+			id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+			fmt.Fprintf(w, `{"code": 200, "data": {"results": [{"id": %s}]}}`, id)
+		}))
+	summary := func(resource string, id int) marvel.Summary {
+		return marvel.Summary{ResourceURI: fmt.Sprintf("http://gateway.marvel.com/v1/public/%s/%d", resource, id)}
+	}
+
+	char, err := c.ResolveCharacter(marvel.CharacterSummary{Summary: summary("characters", 1)})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, char.ID)
+	comic, err := c.ResolveComic(marvel.ComicSummary{Summary: summary("comics", 2)})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, comic.ID)
+	creator, err := c.ResolveCreator(marvel.CreatorSummary{Summary: summary("creators", 3)})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, creator.ID)
+	event, err := c.ResolveEvent(marvel.EventSummary{Summary: summary("events", 4)})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, event.ID)
+	series, err := c.ResolveSeries(marvel.SeriesSummary{Summary: summary("series", 5)})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, series.ID)
+	story, err := c.ResolveStory(marvel.StorySummary{Summary: summary("stories", 6)})
+	assert.NoError(t, err)
+	assert.Equal(t, 6, story.ID)
+
+	assert.Equal(t, []string{
+		"/v1/public/characters/1",
+		"/v1/public/comics/2",
+		"/v1/public/creators/3",
+		"/v1/public/events/4",
+		"/v1/public/series/5",
+		"/v1/public/stories/6",
+	}, paths)
+
+	_, err = c.ResolveComic(marvel.ComicSummary{})
+	assert.Error(t, err)
+	assert.Len(t, paths, 6, "Request sent for summary without an ID")
+}
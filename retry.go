@@ -0,0 +1,185 @@
+package marvel
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RetryPolicy configures how a Client retries requests which fail transiently.
+// Network errors are always retried, unless the request's context is done.
+// Errors from the Client's RateLimiter, such as ErrQuotaExceeded, never are.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first. Values
+	// less than 2 disable retries.
+	MaxAttempts int
+	// BaseBackoff is the wait before the first retry. It doubles for each
+	// subsequent retry, up to MaxBackoff. A response's Retry-After header is
+	// waited for instead, but if it asks for more than MaxBackoff, the response
+	// is returned without retrying.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// Jitter is the fraction, between 0 and 1, of each backoff which is
+	// randomized to spread out retries from concurrent requests.
+	Jitter float64
+	// StatusCodes are the HTTP response statuses which are retried.
+	StatusCodes []int
+	// APICodes are the APIError codes which are retried, regardless of the
+	// HTTP status.
+	APICodes []ErrorCode
+}
+
+// DefaultRetryPolicy returns a RetryPolicy suitable for most uses. It retries
+// throttled requests and server errors up to three times.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		BaseBackoff: 500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+		Jitter:      0.2,
+		StatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		APICodes: []ErrorCode{CodeRequestThrottled},
+	}
+}
+
+// backoff returns the wait before the given retry, counting from 1.
+func (rp *RetryPolicy) backoff(retry int) time.Duration {
+	d := rp.BaseBackoff
+	for i := 1; i < retry && d < rp.MaxBackoff; i++ {
+		d *= 2
+	}
+	if rp.MaxBackoff > 0 && d > rp.MaxBackoff {
+		d = rp.MaxBackoff
+	}
+	if rp.Jitter > 0 {
+		d -= time.Duration(rp.Jitter * rand.Float64() * float64(d))
+	}
+	return d
+}
+
+// retryable reports whether the response should be retried. The response body
+// is restored if it was read to find the APIError code.
+func (rp *RetryPolicy) retryable(resp *http.Response) bool {
+	for _, code := range rp.StatusCodes {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+	if len(rp.APICodes) == 0 || resp.StatusCode < 400 {
+		return false
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+	apiErr := &APIError{}
+	if json.Unmarshal(body, apiErr) != nil || apiErr.Code == "" {
+		return false
+	}
+	for _, code := range rp.APICodes {
+		if apiErr.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// retryAfter returns the wait requested by the response's Retry-After header,
+// given either in seconds or as an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		d := time.Until(at)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// retryTransport is an http.RoundTripper which resends requests according to
+// a RetryPolicy. Each attempt passes through the base transport anew, so it is
+// rate limited and authenticated individually.
+type retryTransport struct {
+	mu     sync.Mutex
+	policy *RetryPolicy
+	base   http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (rt *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.mu.Lock()
+	policy := rt.policy
+	rt.mu.Unlock()
+	if policy == nil || policy.MaxAttempts < 2 {
+		return rt.base.RoundTrip(req)
+	}
+
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		resp, err := rt.base.RoundTrip(req.Clone(ctx))
+		var limitErr *limiterError
+		if errors.As(err, &limitErr) {
+			return nil, limitErr.err
+		}
+		if attempt >= policy.MaxAttempts || ctx.Err() != nil {
+			return resp, err
+		}
+
+		var wait time.Duration
+		if err != nil {
+			wait = policy.backoff(attempt)
+		} else if policy.retryable(resp) {
+			if after, ok := retryAfter(resp); ok {
+				if policy.MaxBackoff > 0 && after > policy.MaxBackoff {
+					return resp, nil
+				}
+				wait = after
+			} else {
+				wait = policy.backoff(attempt)
+			}
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		} else {
+			return resp, nil
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// setPolicy replaces the RetryPolicy used for subsequent requests.
+func (rt *retryTransport) setPolicy(policy *RetryPolicy) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	rt.policy = policy
+}
@@ -0,0 +1,165 @@
+package marvel_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dustinrc/marvel"
+	"github.com/stretchr/testify/assert"
+)
+
+// failingHandler fails the first failures requests with the given status and
+// body, then succeeds. The timestamp of every request is recorded.
+type failingHandler struct {
+	failures   int
+	status     int
+	body       string
+	header     http.Header
+	timestamps []string
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (fh *failingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fh.timestamps = append(fh.timestamps, r.URL.Query().Get("ts"))
+	if len(fh.timestamps) <= fh.failures {
+		for key, values := range fh.header {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(fh.status)
+		fmt.Fprint(w, fh.body)
+		return
+	}
+	fmt.Fprint(w, `{"code": 200, "data": {"results": [{"id": 7}]}}`)
+}
+
+// fastRetryPolicy returns the default policy with very short backoffs.
+func fastRetryPolicy() *marvel.RetryPolicy {
+	policy := marvel.DefaultRetryPolicy()
+	policy.BaseBackoff = time.Millisecond
+	policy.MaxBackoff = 5 * time.Millisecond
+	return policy
+}
+
+func TestClientRetry(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		handler  *failingHandler
+		attempts int
+		success  bool
+	}{
+		{
+			desc:     "server errors are retried",
+			handler:  &failingHandler{failures: 2, status: 503, body: `<html>unavailable</html>`},
+			attempts: 3,
+			success:  true,
+		},
+		{
+			desc:     "throttled requests are retried",
+			handler:  &failingHandler{failures: 1, status: 429, body: `{"code": "RequestThrottled", "message": "slow down"}`},
+			attempts: 2,
+			success:  true,
+		},
+		{
+			desc:     "retryable API codes are retried for any status",
+			handler:  &failingHandler{failures: 1, status: 403, body: `{"code": "RequestThrottled", "message": "slow down"}`},
+			attempts: 2,
+			success:  true,
+		},
+		{
+			desc:     "usage errors are not retried",
+			handler:  &failingHandler{failures: 1, status: 409, body: `{"code": 409, "message": "bad param"}`},
+			attempts: 1,
+		},
+		{
+			desc:     "attempts are limited",
+			handler:  &failingHandler{failures: 10, status: 500, body: `{"code": 500, "message": "oops"}`},
+			attempts: 4,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			c := newServerClient(t, marvel.NewServerSideAuth("1234", "abcd"), tC.handler)
+			c.Retry(fastRetryPolicy())
+
+			comic, err := c.Comics.Get(7)
+			if tC.success {
+				assert.NoError(t, err)
+				assert.Equal(t, 7, comic.ID)
+			} else {
+				assert.Error(t, err)
+			}
+			assert.Len(t, tC.handler.timestamps, tC.attempts)
+			for i := 1; i < len(tC.handler.timestamps); i++ {
+				assert.NotEqual(t, tC.handler.timestamps[i-1], tC.handler.timestamps[i], "Auth not regenerated for retry")
+			}
+			assert.Equal(t, tC.attempts, c.CallsToday())
+		})
+	}
+}
+
+func TestClientRetryAfter(t *testing.T) {
+	policy := fastRetryPolicy()
+	policy.MaxBackoff = 2 * time.Second
+
+	t.Run("Retry-After is honored", func(t *testing.T) {
+		fh := &failingHandler{
+			failures: 1,
+			status:   429,
+			body:     `{"code": "RequestThrottled", "message": "slow down"}`,
+			header:   http.Header{"Retry-After": []string{"1"}},
+		}
+		c := newServerClient(t, &mockAuth{}, fh)
+		c.Retry(policy)
+
+		start := time.Now()
+		_, err := c.Events.Get(7)
+		assert.NoError(t, err)
+		assert.True(t, time.Since(start) >= time.Second, "Retry-After was not honored")
+		assert.Len(t, fh.timestamps, 2)
+	})
+	t.Run("Retry-After over MaxBackoff is not waited for", func(t *testing.T) {
+		fh := &failingHandler{
+			failures: 1,
+			status:   503,
+			body:     `{"code": 503, "message": "down for maintenance"}`,
+			header:   http.Header{"Retry-After": []string{"86400"}},
+		}
+		c := newServerClient(t, &mockAuth{}, fh)
+		c.Retry(policy)
+
+		start := time.Now()
+		_, err := c.Events.Get(7)
+		assert.Error(t, err)
+		assert.True(t, time.Since(start) < time.Second, "Retry-After was waited for")
+		assert.Len(t, fh.timestamps, 1)
+	})
+}
+
+func TestClientRetryDisabled(t *testing.T) {
+	fh := &failingHandler{failures: 1, status: 503, body: `{"code": 503, "message": "down"}`}
+	c := newServerClient(t, &mockAuth{}, fh)
+
+	_, err := c.Series.Get(7)
+	assert.Error(t, err)
+	assert.Len(t, fh.timestamps, 1)
+}
+
+func TestClientRetryQuotaExceeded(t *testing.T) {
+	fh := &failingHandler{}
+	srv := httptest.NewServer(fh)
+	defer srv.Close()
+	c := marvel.NewClient(&mockAuth{}, nil, marvel.WithBaseURL(srv.URL),
+		marvel.WithRateLimit(marvel.NewQuotaLimiter(0, 1)), marvel.WithRetry(marvel.DefaultRetryPolicy()))
+
+	_, err := c.Creators.Get(7)
+	assert.NoError(t, err)
+	start := time.Now()
+	_, err = c.Creators.Get(7)
+	assert.True(t, errors.Is(err, marvel.ErrQuotaExceeded), "Expected quota error, got %v", err)
+	assert.True(t, time.Since(start) < 100*time.Millisecond, "Spent quota was retried")
+	assert.Len(t, fh.timestamps, 1)
+}
@@ -1,6 +1,7 @@
 package marvel
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -23,107 +24,308 @@ func NewSeriesService(sling *sling.Sling) *SeriesService {
 // AllWrapped returns all series that match the query parameters. The series
 // slice will be encapsulated by SeriesDataContainer and SeriesDataWrapper.
 func (srs *SeriesService) AllWrapped(params *SeriesParams) (*SeriesDataWrapper, *http.Response, error) {
+	return srs.AllWrappedContext(context.Background(), params)
+}
+
+// AllWrappedContext is like AllWrapped, but the request is sent using ctx.
+func (srs *SeriesService) AllWrappedContext(ctx context.Context, params *SeriesParams) (*SeriesDataWrapper, *http.Response, error) {
 	wrap := &SeriesDataWrapper{}
-	resp, err := receiveWrapped(srs.sling, "../series", wrap, params)
+	resp, err := receiveWrapped(ctx, srs.sling, "../series", wrap, params)
 	return wrap, resp, err
 }
 
 // All returns all series that match the query parameters.
 func (srs *SeriesService) All(params *SeriesParams) ([]Series, error) {
-	wrap, _, err := srs.AllWrapped(params)
-	return wrap.Data.Results, err
+	return srs.AllContext(context.Background(), params)
+}
+
+// AllContext is like All, but the request is sent using ctx.
+func (srs *SeriesService) AllContext(ctx context.Context, params *SeriesParams) ([]Series, error) {
+	wrap, _, err := srs.AllWrappedContext(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
 }
 
 // GetWrapped returns the series associated with the given ID. The series
 // details will be encapsulated by SeriesDataContainer and SeriesDataWrapper.
 func (srs *SeriesService) GetWrapped(seriesID int) (*SeriesDataWrapper, *http.Response, error) {
+	return srs.GetWrappedContext(context.Background(), seriesID)
+}
+
+// GetWrappedContext is like GetWrapped, but the request is sent using ctx.
+func (srs *SeriesService) GetWrappedContext(ctx context.Context, seriesID int) (*SeriesDataWrapper, *http.Response, error) {
 	wrap := &SeriesDataWrapper{}
-	resp, err := receiveWrapped(srs.sling, fmt.Sprintf("%d", seriesID), wrap, nil)
+	resp, err := receiveWrapped(ctx, srs.sling, fmt.Sprintf("%d", seriesID), wrap, nil)
 	return wrap, resp, err
 }
 
-// Get returns the series associated with the given ID.
+// Get returns the series associated with the given ID. A NotFoundError is
+// returned if there is no such series.
 func (srs *SeriesService) Get(seriesID int) (*Series, error) {
-	wrap, _, err := srs.GetWrapped(seriesID)
-	if err != nil {
+	return srs.GetContext(context.Background(), seriesID)
+}
+
+// GetContext is like Get, but the request is sent using ctx.
+func (srs *SeriesService) GetContext(ctx context.Context, seriesID int) (*Series, error) {
+	wrap, _, err := srs.GetWrappedContext(ctx, seriesID)
+	if err = getError(SeriesResource, seriesID, err, len(wrap.Data.Results)); err != nil {
 		return nil, err
 	}
 	return &wrap.Data.Results[0], nil
 }
 
+// GetMany returns the series associated with the given IDs, keyed by ID. The
+// series are requested concurrently, at most workers at a time, and any errors
+// are returned keyed by the ID that caused them. A workers value less than 1 is
+// treated as 1.
+func (srs *SeriesService) GetMany(seriesIDs []int, workers int) (map[int]*Series, map[int]error) {
+	return srs.GetManyContext(context.Background(), seriesIDs, workers)
+}
+
+// GetManyContext is like GetMany, but the requests are sent using ctx.
+func (srs *SeriesService) GetManyContext(ctx context.Context, seriesIDs []int, workers int) (map[int]*Series, map[int]error) {
+	values, errs := getMany(ctx, seriesIDs, workers, func(ctx context.Context, id int) (interface{}, error) {
+		return srs.GetContext(ctx, id)
+	})
+	series := make(map[int]*Series, len(values))
+	for id, v := range values {
+		series[id] = v.(*Series)
+	}
+	return series, errs
+}
+
 // CharactersWrapped returns all characters involving the given series and match the
 // query parameters. The character slice will be encapsulated by CharacterDataContainer
 // and CharacterDataWrapper.
 func (srs *SeriesService) CharactersWrapped(seriesID int, params *CharacterParams) (*CharacterDataWrapper, *http.Response, error) {
+	return srs.CharactersWrappedContext(context.Background(), seriesID, params)
+}
+
+// CharactersWrappedContext is like CharactersWrapped, but the request is sent using ctx.
+func (srs *SeriesService) CharactersWrappedContext(ctx context.Context, seriesID int, params *CharacterParams) (*CharacterDataWrapper, *http.Response, error) {
 	wrap := &CharacterDataWrapper{}
-	resp, err := receiveWrapped(srs.sling, fmt.Sprintf("%d/characters", seriesID), wrap, params)
+	resp, err := receiveWrapped(ctx, srs.sling, fmt.Sprintf("%d/characters", seriesID), wrap, params)
 	return wrap, resp, err
 }
 
 // Characters returns all characters involving the given series and match the query parameters.
 func (srs *SeriesService) Characters(seriesID int, params *CharacterParams) ([]Character, error) {
-	wrap, _, err := srs.CharactersWrapped(seriesID, params)
-	return wrap.Data.Results, err
+	return srs.CharactersContext(context.Background(), seriesID, params)
+}
+
+// CharactersContext is like Characters, but the request is sent using ctx.
+func (srs *SeriesService) CharactersContext(ctx context.Context, seriesID int, params *CharacterParams) ([]Character, error) {
+	wrap, _, err := srs.CharactersWrappedContext(ctx, seriesID, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
 }
 
 // ComicsWrapped returns all comics involving the given series and match the
 // query parameters. The comic slice will be encapsulated by ComicDataContainer
 // and ComicDataWrapper.
 func (srs *SeriesService) ComicsWrapped(seriesID int, params *ComicParams) (*ComicDataWrapper, *http.Response, error) {
+	return srs.ComicsWrappedContext(context.Background(), seriesID, params)
+}
+
+// ComicsWrappedContext is like ComicsWrapped, but the request is sent using ctx.
+func (srs *SeriesService) ComicsWrappedContext(ctx context.Context, seriesID int, params *ComicParams) (*ComicDataWrapper, *http.Response, error) {
 	wrap := &ComicDataWrapper{}
-	resp, err := receiveWrapped(srs.sling, fmt.Sprintf("%d/comics", seriesID), wrap, params)
+	resp, err := receiveWrapped(ctx, srs.sling, fmt.Sprintf("%d/comics", seriesID), wrap, params)
 	return wrap, resp, err
 }
 
 // Comics returns all comics involving the given series and match the query parameters.
 func (srs *SeriesService) Comics(seriesID int, params *ComicParams) ([]Comic, error) {
-	wrap, _, err := srs.ComicsWrapped(seriesID, params)
-	return wrap.Data.Results, err
+	return srs.ComicsContext(context.Background(), seriesID, params)
+}
+
+// ComicsContext is like Comics, but the request is sent using ctx.
+func (srs *SeriesService) ComicsContext(ctx context.Context, seriesID int, params *ComicParams) ([]Comic, error) {
+	wrap, _, err := srs.ComicsWrappedContext(ctx, seriesID, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
 }
 
 // CreatorsWrapped returns all creators involving the given series and match the
 // query parameters. The creator slice will be encapsulated by CreatorDataContainer
 // and CreatorDataWrapper.
 func (srs *SeriesService) CreatorsWrapped(seriesID int, params *CreatorParams) (*CreatorDataWrapper, *http.Response, error) {
+	return srs.CreatorsWrappedContext(context.Background(), seriesID, params)
+}
+
+// CreatorsWrappedContext is like CreatorsWrapped, but the request is sent using ctx.
+func (srs *SeriesService) CreatorsWrappedContext(ctx context.Context, seriesID int, params *CreatorParams) (*CreatorDataWrapper, *http.Response, error) {
 	wrap := &CreatorDataWrapper{}
-	resp, err := receiveWrapped(srs.sling, fmt.Sprintf("%d/creators", seriesID), wrap, params)
+	resp, err := receiveWrapped(ctx, srs.sling, fmt.Sprintf("%d/creators", seriesID), wrap, params)
 	return wrap, resp, err
 }
 
 // Creators returns all creators involving the given series and match the query parameters.
 func (srs *SeriesService) Creators(seriesID int, params *CreatorParams) ([]Creator, error) {
-	wrap, _, err := srs.CreatorsWrapped(seriesID, params)
-	return wrap.Data.Results, err
+	return srs.CreatorsContext(context.Background(), seriesID, params)
+}
+
+// CreatorsContext is like Creators, but the request is sent using ctx.
+func (srs *SeriesService) CreatorsContext(ctx context.Context, seriesID int, params *CreatorParams) ([]Creator, error) {
+	wrap, _, err := srs.CreatorsWrappedContext(ctx, seriesID, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
 }
 
 // EventsWrapped returns all events involving the given series and match the
 // query parameters. The event slice will be encapsulated by EventDataContainer
 // and EventDataWrapper.
 func (srs *SeriesService) EventsWrapped(eventID int, params *EventParams) (*EventDataWrapper, *http.Response, error) {
+	return srs.EventsWrappedContext(context.Background(), eventID, params)
+}
+
+// EventsWrappedContext is like EventsWrapped, but the request is sent using ctx.
+func (srs *SeriesService) EventsWrappedContext(ctx context.Context, eventID int, params *EventParams) (*EventDataWrapper, *http.Response, error) {
 	wrap := &EventDataWrapper{}
-	resp, err := receiveWrapped(srs.sling, fmt.Sprintf("%d/events", eventID), wrap, params)
+	resp, err := receiveWrapped(ctx, srs.sling, fmt.Sprintf("%d/events", eventID), wrap, params)
 	return wrap, resp, err
 }
 
 // Events returns all events involving the given series and match the query parameters.
 func (srs *SeriesService) Events(eventID int, params *EventParams) ([]Event, error) {
-	wrap, _, err := srs.EventsWrapped(eventID, params)
-	return wrap.Data.Results, err
+	return srs.EventsContext(context.Background(), eventID, params)
+}
+
+// EventsContext is like Events, but the request is sent using ctx.
+func (srs *SeriesService) EventsContext(ctx context.Context, eventID int, params *EventParams) ([]Event, error) {
+	wrap, _, err := srs.EventsWrappedContext(ctx, eventID, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
 }
 
 // StoriesWrapped returns all stories involving the given series and match the
 // query parameters. The story slice will be encapsulated by StoryDataContainer
 // and StoryDataWrapper.
 func (srs *SeriesService) StoriesWrapped(seriesID int, params *StoryParams) (*StoryDataWrapper, *http.Response, error) {
+	return srs.StoriesWrappedContext(context.Background(), seriesID, params)
+}
+
+// StoriesWrappedContext is like StoriesWrapped, but the request is sent using ctx.
+func (srs *SeriesService) StoriesWrappedContext(ctx context.Context, seriesID int, params *StoryParams) (*StoryDataWrapper, *http.Response, error) {
 	wrap := &StoryDataWrapper{}
-	resp, err := receiveWrapped(srs.sling, fmt.Sprintf("%d/stories", seriesID), wrap, params)
+	resp, err := receiveWrapped(ctx, srs.sling, fmt.Sprintf("%d/stories", seriesID), wrap, params)
 	return wrap, resp, err
 }
 
 // Stories returns all stories involving the given series and match the query parameters.
 func (srs *SeriesService) Stories(seriesID int, params *StoryParams) ([]Story, error) {
-	wrap, _, err := srs.StoriesWrapped(seriesID, params)
-	return wrap.Data.Results, err
+	return srs.StoriesContext(context.Background(), seriesID, params)
+}
+
+// StoriesContext is like Stories, but the request is sent using ctx.
+func (srs *SeriesService) StoriesContext(ctx context.Context, seriesID int, params *StoryParams) ([]Story, error) {
+	wrap, _, err := srs.StoriesWrappedContext(ctx, seriesID, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
+}
+
+// AllIter returns a SeriesIterator over all series that match the query
+// parameters, regardless of how many pages of results there are.
+func (srs *SeriesService) AllIter(params *SeriesParams) *SeriesIterator {
+	return srs.AllIterContext(context.Background(), params)
+}
+
+// AllIterContext is like AllIter, but the requests are sent using ctx.
+func (srs *SeriesService) AllIterContext(ctx context.Context, params *SeriesParams) *SeriesIterator {
+	return NewSeriesIterator(ctx, params, srs.AllWrappedContext)
+}
+
+// Walk calls fn for all series that match the query parameters, regardless
+// of how many pages of results there are.
+func (srs *SeriesService) Walk(params *SeriesParams, fn func(Series) error) error {
+	return srs.WalkContext(context.Background(), params, fn)
+}
+
+// WalkContext is like Walk, but the requests are sent using ctx.
+func (srs *SeriesService) WalkContext(ctx context.Context, params *SeriesParams, fn func(Series) error) error {
+	return srs.AllIterContext(ctx, params).Walk(fn)
+}
+
+// CharactersIter returns a CharacterIterator over all characters involving the given
+// series and match the query parameters, regardless of how many pages of
+// results there are.
+func (srs *SeriesService) CharactersIter(seriesID int, params *CharacterParams) *CharacterIterator {
+	return srs.CharactersIterContext(context.Background(), seriesID, params)
+}
+
+// CharactersIterContext is like CharactersIter, but the requests are sent using ctx.
+func (srs *SeriesService) CharactersIterContext(ctx context.Context, seriesID int, params *CharacterParams) *CharacterIterator {
+	return NewCharacterIterator(ctx, params, func(ctx context.Context, params *CharacterParams) (*CharacterDataWrapper, *http.Response, error) {
+		return srs.CharactersWrappedContext(ctx, seriesID, params)
+	})
+}
+
+// ComicsIter returns a ComicIterator over all comics involving the given
+// series and match the query parameters, regardless of how many pages of
+// results there are.
+func (srs *SeriesService) ComicsIter(seriesID int, params *ComicParams) *ComicIterator {
+	return srs.ComicsIterContext(context.Background(), seriesID, params)
+}
+
+// ComicsIterContext is like ComicsIter, but the requests are sent using ctx.
+func (srs *SeriesService) ComicsIterContext(ctx context.Context, seriesID int, params *ComicParams) *ComicIterator {
+	return NewComicIterator(ctx, params, func(ctx context.Context, params *ComicParams) (*ComicDataWrapper, *http.Response, error) {
+		return srs.ComicsWrappedContext(ctx, seriesID, params)
+	})
+}
+
+// CreatorsIter returns a CreatorIterator over all creators involving the given
+// series and match the query parameters, regardless of how many pages of
+// results there are.
+func (srs *SeriesService) CreatorsIter(seriesID int, params *CreatorParams) *CreatorIterator {
+	return srs.CreatorsIterContext(context.Background(), seriesID, params)
+}
+
+// CreatorsIterContext is like CreatorsIter, but the requests are sent using ctx.
+func (srs *SeriesService) CreatorsIterContext(ctx context.Context, seriesID int, params *CreatorParams) *CreatorIterator {
+	return NewCreatorIterator(ctx, params, func(ctx context.Context, params *CreatorParams) (*CreatorDataWrapper, *http.Response, error) {
+		return srs.CreatorsWrappedContext(ctx, seriesID, params)
+	})
+}
+
+// EventsIter returns an EventIterator over all events involving the given
+// series and match the query parameters, regardless of how many pages of
+// results there are.
+func (srs *SeriesService) EventsIter(seriesID int, params *EventParams) *EventIterator {
+	return srs.EventsIterContext(context.Background(), seriesID, params)
+}
+
+// EventsIterContext is like EventsIter, but the requests are sent using ctx.
+func (srs *SeriesService) EventsIterContext(ctx context.Context, seriesID int, params *EventParams) *EventIterator {
+	return NewEventIterator(ctx, params, func(ctx context.Context, params *EventParams) (*EventDataWrapper, *http.Response, error) {
+		return srs.EventsWrappedContext(ctx, seriesID, params)
+	})
+}
+
+// StoriesIter returns a StoryIterator over all stories involving the given
+// series and match the query parameters, regardless of how many pages of
+// results there are.
+func (srs *SeriesService) StoriesIter(seriesID int, params *StoryParams) *StoryIterator {
+	return srs.StoriesIterContext(context.Background(), seriesID, params)
+}
+
+// StoriesIterContext is like StoriesIter, but the requests are sent using ctx.
+func (srs *SeriesService) StoriesIterContext(ctx context.Context, seriesID int, params *StoryParams) *StoryIterator {
+	return NewStoryIterator(ctx, params, func(ctx context.Context, params *StoryParams) (*StoryDataWrapper, *http.Response, error) {
+		return srs.StoriesWrappedContext(ctx, seriesID, params)
+	})
 }
 
 // SeriesDataWrapper provides series wrapper information returned by the API.
@@ -163,20 +365,78 @@ type Series struct {
 // SeriesParams are optional parameters to narrow the series results returned
 // by the API, as well as specify the number and order.
 type SeriesParams struct {
-	Title           string    `url:"title,omitempty"`
-	TitleStartsWith string    `url:"titleStartsWith,omitempty"`
-	StartYear       int       `url:"startYear,omitempty"`
-	ModifiedSince   time.Time `url:"modifiedSince,omitempty"`
-	Comics          []int     `url:"comics,omitempty"`
-	Stories         []int     `url:"stories,omitempty"`
-	Events          []int     `url:"events,omitempty"`
-	Creators        []int     `url:"creators,omitempty"`
-	Characters      []int     `url:"characters,omitempty"`
-	SeriesType      string    `url:"seriesType,omitempty"`
-	Contains        string    `url:"contains,omitempty"`
-	OrderBy         string    `url:"orderBy,omitempty"`
-	Limit           int       `url:"limit,omitempty"`
-	Offset          int       `url:"offset,omitempty"`
+	Title           string        `url:"title,omitempty"`
+	TitleStartsWith string        `url:"titleStartsWith,omitempty"`
+	StartYear       int           `url:"startYear,omitempty"`
+	ModifiedSince   time.Time     `url:"modifiedSince,omitempty" layout:"2006-01-02T15:04:05-0700"`
+	Comics          IDList        `url:"comics,omitempty"`
+	Stories         IDList        `url:"stories,omitempty"`
+	Events          IDList        `url:"events,omitempty"`
+	Creators        IDList        `url:"creators,omitempty"`
+	Characters      IDList        `url:"characters,omitempty"`
+	SeriesType      SeriesType    `url:"seriesType,omitempty"`
+	Contains        FormatList    `url:"contains,omitempty"`
+	OrderBy         SeriesOrderBy `url:"orderBy,omitempty"`
+	Limit           int           `url:"limit,omitempty"`
+	Offset          int           `url:"offset,omitempty"`
+}
+
+// SeriesOrderBy is the order of a list of series. Orders may be joined with
+// commas, in decreasing priority, e.g., "title,-modified".
+type SeriesOrderBy string
+
+// The orders in which series can be listed. The Desc variants sort in
+// descending order.
+const (
+	SeriesOrderByTitle         SeriesOrderBy = "title"
+	SeriesOrderByTitleDesc     SeriesOrderBy = "-title"
+	SeriesOrderByStartYear     SeriesOrderBy = "startYear"
+	SeriesOrderByStartYearDesc SeriesOrderBy = "-startYear"
+	SeriesOrderByModified      SeriesOrderBy = "modified"
+	SeriesOrderByModifiedDesc  SeriesOrderBy = "-modified"
+)
+
+// valid reports whether the API accepts the order.
+func (o SeriesOrderBy) valid() bool {
+	return validOrder(string(o), "title", "startYear", "modified")
+}
+
+// Validate returns a *ValidationError listing each of the parameters the API
+// would reject. It is called before each request is sent with the parameters.
+func (p *SeriesParams) Validate() error {
+	if p == nil {
+		return nil
+	}
+	v := newValidation("SeriesParams")
+	v.text("Title", p.Title)
+	v.text("TitleStartsWith", p.TitleStartsWith)
+	v.ids("Comics", p.Comics)
+	v.ids("Stories", p.Stories)
+	v.ids("Events", p.Events)
+	v.ids("Creators", p.Creators)
+	v.ids("Characters", p.Characters)
+	v.enum(p.SeriesType.valid(), "SeriesType", string(p.SeriesType))
+	v.enum(p.Contains.valid(), "Contains", p.Contains.String())
+	v.enum(p.OrderBy.valid(), "OrderBy", string(p.OrderBy))
+	v.page(p.Limit, p.Offset)
+	return v.err()
+}
+
+// SeriesType is the publication type of a series.
+type SeriesType string
+
+// The types of series.
+const (
+	SeriesTypeCollection SeriesType = "collection"
+	SeriesTypeOneShot    SeriesType = "one shot"
+	SeriesTypeLimited    SeriesType = "limited"
+	SeriesTypeOngoing    SeriesType = "ongoing"
+)
+
+// valid reports whether the API accepts the series type.
+func (st SeriesType) valid() bool {
+	return oneOf(string(st), string(SeriesTypeCollection), string(SeriesTypeOneShot),
+		string(SeriesTypeLimited), string(SeriesTypeOngoing))
 }
 
 // SeriesList provides series related to the parent entity.
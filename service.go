@@ -0,0 +1,288 @@
+package marvel
+
+import (
+	"context"
+	"net/http"
+)
+
+// The service interfaces are implemented by the Client's services. Code which
+// accepts them, rather than the services themselves, can be tested against a
+// substitute, such as a Client from the marveltest package or a mock.
+var (
+	_ CharacterAPI = (*CharacterService)(nil)
+	_ ComicAPI     = (*ComicService)(nil)
+	_ CreatorAPI   = (*CreatorService)(nil)
+	_ EventAPI     = (*EventService)(nil)
+	_ SeriesAPI    = (*SeriesService)(nil)
+	_ StoryAPI     = (*StoryService)(nil)
+)
+
+// CharacterAPI is the interface of a CharacterService, for querying characters.
+type CharacterAPI interface {
+	AllWrapped(params *CharacterParams) (*CharacterDataWrapper, *http.Response, error)
+	AllWrappedContext(ctx context.Context, params *CharacterParams) (*CharacterDataWrapper, *http.Response, error)
+	All(params *CharacterParams) ([]Character, error)
+	AllContext(ctx context.Context, params *CharacterParams) ([]Character, error)
+	GetWrapped(characterID int) (*CharacterDataWrapper, *http.Response, error)
+	GetWrappedContext(ctx context.Context, characterID int) (*CharacterDataWrapper, *http.Response, error)
+	Get(characterID int) (*Character, error)
+	GetContext(ctx context.Context, characterID int) (*Character, error)
+	GetMany(characterIDs []int, workers int) (map[int]*Character, map[int]error)
+	GetManyContext(ctx context.Context, characterIDs []int, workers int) (map[int]*Character, map[int]error)
+	ComicsWrapped(characterID int, params *ComicParams) (*ComicDataWrapper, *http.Response, error)
+	ComicsWrappedContext(ctx context.Context, characterID int, params *ComicParams) (*ComicDataWrapper, *http.Response, error)
+	Comics(characterID int, params *ComicParams) ([]Comic, error)
+	ComicsContext(ctx context.Context, characterID int, params *ComicParams) ([]Comic, error)
+	EventsWrapped(characterID int, params *EventParams) (*EventDataWrapper, *http.Response, error)
+	EventsWrappedContext(ctx context.Context, characterID int, params *EventParams) (*EventDataWrapper, *http.Response, error)
+	Events(characterID int, params *EventParams) ([]Event, error)
+	EventsContext(ctx context.Context, characterID int, params *EventParams) ([]Event, error)
+	SeriesWrapped(characterID int, params *SeriesParams) (*SeriesDataWrapper, *http.Response, error)
+	SeriesWrappedContext(ctx context.Context, characterID int, params *SeriesParams) (*SeriesDataWrapper, *http.Response, error)
+	Series(characterID int, params *SeriesParams) ([]Series, error)
+	SeriesContext(ctx context.Context, characterID int, params *SeriesParams) ([]Series, error)
+	StoriesWrapped(characterID int, params *StoryParams) (*StoryDataWrapper, *http.Response, error)
+	StoriesWrappedContext(ctx context.Context, characterID int, params *StoryParams) (*StoryDataWrapper, *http.Response, error)
+	Stories(characterID int, params *StoryParams) ([]Story, error)
+	StoriesContext(ctx context.Context, characterID int, params *StoryParams) ([]Story, error)
+	AllIter(params *CharacterParams) *CharacterIterator
+	AllIterContext(ctx context.Context, params *CharacterParams) *CharacterIterator
+	Walk(params *CharacterParams, fn func(Character) error) error
+	WalkContext(ctx context.Context, params *CharacterParams, fn func(Character) error) error
+	ComicsIter(characterID int, params *ComicParams) *ComicIterator
+	ComicsIterContext(ctx context.Context, characterID int, params *ComicParams) *ComicIterator
+	EventsIter(characterID int, params *EventParams) *EventIterator
+	EventsIterContext(ctx context.Context, characterID int, params *EventParams) *EventIterator
+	SeriesIter(characterID int, params *SeriesParams) *SeriesIterator
+	SeriesIterContext(ctx context.Context, characterID int, params *SeriesParams) *SeriesIterator
+	StoriesIter(characterID int, params *StoryParams) *StoryIterator
+	StoriesIterContext(ctx context.Context, characterID int, params *StoryParams) *StoryIterator
+}
+
+// ComicAPI is the interface of a ComicService, for querying comics.
+type ComicAPI interface {
+	AllWrapped(params *ComicParams) (*ComicDataWrapper, *http.Response, error)
+	AllWrappedContext(ctx context.Context, params *ComicParams) (*ComicDataWrapper, *http.Response, error)
+	All(params *ComicParams) ([]Comic, error)
+	AllContext(ctx context.Context, params *ComicParams) ([]Comic, error)
+	GetWrapped(comicID int) (*ComicDataWrapper, *http.Response, error)
+	GetWrappedContext(ctx context.Context, comicID int) (*ComicDataWrapper, *http.Response, error)
+	Get(comicID int) (*Comic, error)
+	GetContext(ctx context.Context, comicID int) (*Comic, error)
+	GetMany(comicIDs []int, workers int) (map[int]*Comic, map[int]error)
+	GetManyContext(ctx context.Context, comicIDs []int, workers int) (map[int]*Comic, map[int]error)
+	CharactersWrapped(comicID int, params *CharacterParams) (*CharacterDataWrapper, *http.Response, error)
+	CharactersWrappedContext(ctx context.Context, comicID int, params *CharacterParams) (*CharacterDataWrapper, *http.Response, error)
+	Characters(comicID int, params *CharacterParams) ([]Character, error)
+	CharactersContext(ctx context.Context, comicID int, params *CharacterParams) ([]Character, error)
+	CreatorsWrapped(comicID int, params *CreatorParams) (*CreatorDataWrapper, *http.Response, error)
+	CreatorsWrappedContext(ctx context.Context, comicID int, params *CreatorParams) (*CreatorDataWrapper, *http.Response, error)
+	Creators(comicID int, params *CreatorParams) ([]Creator, error)
+	CreatorsContext(ctx context.Context, comicID int, params *CreatorParams) ([]Creator, error)
+	EventsWrapped(comicID int, params *EventParams) (*EventDataWrapper, *http.Response, error)
+	EventsWrappedContext(ctx context.Context, comicID int, params *EventParams) (*EventDataWrapper, *http.Response, error)
+	Events(comicID int, params *EventParams) ([]Event, error)
+	EventsContext(ctx context.Context, comicID int, params *EventParams) ([]Event, error)
+	StoriesWrapped(comicID int, params *StoryParams) (*StoryDataWrapper, *http.Response, error)
+	StoriesWrappedContext(ctx context.Context, comicID int, params *StoryParams) (*StoryDataWrapper, *http.Response, error)
+	Stories(comicID int, params *StoryParams) ([]Story, error)
+	StoriesContext(ctx context.Context, comicID int, params *StoryParams) ([]Story, error)
+	AllIter(params *ComicParams) *ComicIterator
+	AllIterContext(ctx context.Context, params *ComicParams) *ComicIterator
+	Walk(params *ComicParams, fn func(Comic) error) error
+	WalkContext(ctx context.Context, params *ComicParams, fn func(Comic) error) error
+	CharactersIter(comicID int, params *CharacterParams) *CharacterIterator
+	CharactersIterContext(ctx context.Context, comicID int, params *CharacterParams) *CharacterIterator
+	CreatorsIter(comicID int, params *CreatorParams) *CreatorIterator
+	CreatorsIterContext(ctx context.Context, comicID int, params *CreatorParams) *CreatorIterator
+	EventsIter(comicID int, params *EventParams) *EventIterator
+	EventsIterContext(ctx context.Context, comicID int, params *EventParams) *EventIterator
+	StoriesIter(comicID int, params *StoryParams) *StoryIterator
+	StoriesIterContext(ctx context.Context, comicID int, params *StoryParams) *StoryIterator
+}
+
+// CreatorAPI is the interface of a CreatorService, for querying creators.
+type CreatorAPI interface {
+	AllWrapped(params *CreatorParams) (*CreatorDataWrapper, *http.Response, error)
+	AllWrappedContext(ctx context.Context, params *CreatorParams) (*CreatorDataWrapper, *http.Response, error)
+	All(params *CreatorParams) ([]Creator, error)
+	AllContext(ctx context.Context, params *CreatorParams) ([]Creator, error)
+	GetWrapped(creatorID int) (*CreatorDataWrapper, *http.Response, error)
+	GetWrappedContext(ctx context.Context, creatorID int) (*CreatorDataWrapper, *http.Response, error)
+	Get(creatorID int) (*Creator, error)
+	GetContext(ctx context.Context, creatorID int) (*Creator, error)
+	GetMany(creatorIDs []int, workers int) (map[int]*Creator, map[int]error)
+	GetManyContext(ctx context.Context, creatorIDs []int, workers int) (map[int]*Creator, map[int]error)
+	ComicsWrapped(creatorID int, params *ComicParams) (*ComicDataWrapper, *http.Response, error)
+	ComicsWrappedContext(ctx context.Context, creatorID int, params *ComicParams) (*ComicDataWrapper, *http.Response, error)
+	Comics(creatorID int, params *ComicParams) ([]Comic, error)
+	ComicsContext(ctx context.Context, creatorID int, params *ComicParams) ([]Comic, error)
+	EventsWrapped(creatorID int, params *EventParams) (*EventDataWrapper, *http.Response, error)
+	EventsWrappedContext(ctx context.Context, creatorID int, params *EventParams) (*EventDataWrapper, *http.Response, error)
+	Events(creatorID int, params *EventParams) ([]Event, error)
+	EventsContext(ctx context.Context, creatorID int, params *EventParams) ([]Event, error)
+	SeriesWrapped(creatorID int, params *SeriesParams) (*SeriesDataWrapper, *http.Response, error)
+	SeriesWrappedContext(ctx context.Context, creatorID int, params *SeriesParams) (*SeriesDataWrapper, *http.Response, error)
+	Series(creatorID int, params *SeriesParams) ([]Series, error)
+	SeriesContext(ctx context.Context, creatorID int, params *SeriesParams) ([]Series, error)
+	StoriesWrapped(creatorID int, params *StoryParams) (*StoryDataWrapper, *http.Response, error)
+	StoriesWrappedContext(ctx context.Context, creatorID int, params *StoryParams) (*StoryDataWrapper, *http.Response, error)
+	Stories(creatorID int, params *StoryParams) ([]Story, error)
+	StoriesContext(ctx context.Context, creatorID int, params *StoryParams) ([]Story, error)
+	AllIter(params *CreatorParams) *CreatorIterator
+	AllIterContext(ctx context.Context, params *CreatorParams) *CreatorIterator
+	Walk(params *CreatorParams, fn func(Creator) error) error
+	WalkContext(ctx context.Context, params *CreatorParams, fn func(Creator) error) error
+	ComicsIter(creatorID int, params *ComicParams) *ComicIterator
+	ComicsIterContext(ctx context.Context, creatorID int, params *ComicParams) *ComicIterator
+	EventsIter(creatorID int, params *EventParams) *EventIterator
+	EventsIterContext(ctx context.Context, creatorID int, params *EventParams) *EventIterator
+	SeriesIter(creatorID int, params *SeriesParams) *SeriesIterator
+	SeriesIterContext(ctx context.Context, creatorID int, params *SeriesParams) *SeriesIterator
+	StoriesIter(creatorID int, params *StoryParams) *StoryIterator
+	StoriesIterContext(ctx context.Context, creatorID int, params *StoryParams) *StoryIterator
+}
+
+// EventAPI is the interface of a EventService, for querying events.
+type EventAPI interface {
+	AllWrapped(params *EventParams) (*EventDataWrapper, *http.Response, error)
+	AllWrappedContext(ctx context.Context, params *EventParams) (*EventDataWrapper, *http.Response, error)
+	All(params *EventParams) ([]Event, error)
+	AllContext(ctx context.Context, params *EventParams) ([]Event, error)
+	GetWrapped(eventID int) (*EventDataWrapper, *http.Response, error)
+	GetWrappedContext(ctx context.Context, eventID int) (*EventDataWrapper, *http.Response, error)
+	Get(eventID int) (*Event, error)
+	GetContext(ctx context.Context, eventID int) (*Event, error)
+	GetMany(eventIDs []int, workers int) (map[int]*Event, map[int]error)
+	GetManyContext(ctx context.Context, eventIDs []int, workers int) (map[int]*Event, map[int]error)
+	CharactersWrapped(eventID int, params *CharacterParams) (*CharacterDataWrapper, *http.Response, error)
+	CharactersWrappedContext(ctx context.Context, eventID int, params *CharacterParams) (*CharacterDataWrapper, *http.Response, error)
+	Characters(eventID int, params *CharacterParams) ([]Character, error)
+	CharactersContext(ctx context.Context, eventID int, params *CharacterParams) ([]Character, error)
+	ComicsWrapped(eventID int, params *ComicParams) (*ComicDataWrapper, *http.Response, error)
+	ComicsWrappedContext(ctx context.Context, eventID int, params *ComicParams) (*ComicDataWrapper, *http.Response, error)
+	Comics(eventID int, params *ComicParams) ([]Comic, error)
+	ComicsContext(ctx context.Context, eventID int, params *ComicParams) ([]Comic, error)
+	CreatorsWrapped(eventID int, params *CreatorParams) (*CreatorDataWrapper, *http.Response, error)
+	CreatorsWrappedContext(ctx context.Context, eventID int, params *CreatorParams) (*CreatorDataWrapper, *http.Response, error)
+	Creators(eventID int, params *CreatorParams) ([]Creator, error)
+	CreatorsContext(ctx context.Context, eventID int, params *CreatorParams) ([]Creator, error)
+	SeriesWrapped(eventID int, params *SeriesParams) (*SeriesDataWrapper, *http.Response, error)
+	SeriesWrappedContext(ctx context.Context, eventID int, params *SeriesParams) (*SeriesDataWrapper, *http.Response, error)
+	Series(eventID int, params *SeriesParams) ([]Series, error)
+	SeriesContext(ctx context.Context, eventID int, params *SeriesParams) ([]Series, error)
+	StoriesWrapped(eventID int, params *StoryParams) (*StoryDataWrapper, *http.Response, error)
+	StoriesWrappedContext(ctx context.Context, eventID int, params *StoryParams) (*StoryDataWrapper, *http.Response, error)
+	Stories(eventID int, params *StoryParams) ([]Story, error)
+	StoriesContext(ctx context.Context, eventID int, params *StoryParams) ([]Story, error)
+	AllIter(params *EventParams) *EventIterator
+	AllIterContext(ctx context.Context, params *EventParams) *EventIterator
+	Walk(params *EventParams, fn func(Event) error) error
+	WalkContext(ctx context.Context, params *EventParams, fn func(Event) error) error
+	CharactersIter(eventID int, params *CharacterParams) *CharacterIterator
+	CharactersIterContext(ctx context.Context, eventID int, params *CharacterParams) *CharacterIterator
+	ComicsIter(eventID int, params *ComicParams) *ComicIterator
+	ComicsIterContext(ctx context.Context, eventID int, params *ComicParams) *ComicIterator
+	CreatorsIter(eventID int, params *CreatorParams) *CreatorIterator
+	CreatorsIterContext(ctx context.Context, eventID int, params *CreatorParams) *CreatorIterator
+	SeriesIter(eventID int, params *SeriesParams) *SeriesIterator
+	SeriesIterContext(ctx context.Context, eventID int, params *SeriesParams) *SeriesIterator
+	StoriesIter(eventID int, params *StoryParams) *StoryIterator
+	StoriesIterContext(ctx context.Context, eventID int, params *StoryParams) *StoryIterator
+}
+
+// SeriesAPI is the interface of a SeriesService, for querying series.
+type SeriesAPI interface {
+	AllWrapped(params *SeriesParams) (*SeriesDataWrapper, *http.Response, error)
+	AllWrappedContext(ctx context.Context, params *SeriesParams) (*SeriesDataWrapper, *http.Response, error)
+	All(params *SeriesParams) ([]Series, error)
+	AllContext(ctx context.Context, params *SeriesParams) ([]Series, error)
+	GetWrapped(seriesID int) (*SeriesDataWrapper, *http.Response, error)
+	GetWrappedContext(ctx context.Context, seriesID int) (*SeriesDataWrapper, *http.Response, error)
+	Get(seriesID int) (*Series, error)
+	GetContext(ctx context.Context, seriesID int) (*Series, error)
+	GetMany(seriesIDs []int, workers int) (map[int]*Series, map[int]error)
+	GetManyContext(ctx context.Context, seriesIDs []int, workers int) (map[int]*Series, map[int]error)
+	CharactersWrapped(seriesID int, params *CharacterParams) (*CharacterDataWrapper, *http.Response, error)
+	CharactersWrappedContext(ctx context.Context, seriesID int, params *CharacterParams) (*CharacterDataWrapper, *http.Response, error)
+	Characters(seriesID int, params *CharacterParams) ([]Character, error)
+	CharactersContext(ctx context.Context, seriesID int, params *CharacterParams) ([]Character, error)
+	ComicsWrapped(seriesID int, params *ComicParams) (*ComicDataWrapper, *http.Response, error)
+	ComicsWrappedContext(ctx context.Context, seriesID int, params *ComicParams) (*ComicDataWrapper, *http.Response, error)
+	Comics(seriesID int, params *ComicParams) ([]Comic, error)
+	ComicsContext(ctx context.Context, seriesID int, params *ComicParams) ([]Comic, error)
+	CreatorsWrapped(seriesID int, params *CreatorParams) (*CreatorDataWrapper, *http.Response, error)
+	CreatorsWrappedContext(ctx context.Context, seriesID int, params *CreatorParams) (*CreatorDataWrapper, *http.Response, error)
+	Creators(seriesID int, params *CreatorParams) ([]Creator, error)
+	CreatorsContext(ctx context.Context, seriesID int, params *CreatorParams) ([]Creator, error)
+	EventsWrapped(eventID int, params *EventParams) (*EventDataWrapper, *http.Response, error)
+	EventsWrappedContext(ctx context.Context, eventID int, params *EventParams) (*EventDataWrapper, *http.Response, error)
+	Events(eventID int, params *EventParams) ([]Event, error)
+	EventsContext(ctx context.Context, eventID int, params *EventParams) ([]Event, error)
+	StoriesWrapped(seriesID int, params *StoryParams) (*StoryDataWrapper, *http.Response, error)
+	StoriesWrappedContext(ctx context.Context, seriesID int, params *StoryParams) (*StoryDataWrapper, *http.Response, error)
+	Stories(seriesID int, params *StoryParams) ([]Story, error)
+	StoriesContext(ctx context.Context, seriesID int, params *StoryParams) ([]Story, error)
+	AllIter(params *SeriesParams) *SeriesIterator
+	AllIterContext(ctx context.Context, params *SeriesParams) *SeriesIterator
+	Walk(params *SeriesParams, fn func(Series) error) error
+	WalkContext(ctx context.Context, params *SeriesParams, fn func(Series) error) error
+	CharactersIter(seriesID int, params *CharacterParams) *CharacterIterator
+	CharactersIterContext(ctx context.Context, seriesID int, params *CharacterParams) *CharacterIterator
+	ComicsIter(seriesID int, params *ComicParams) *ComicIterator
+	ComicsIterContext(ctx context.Context, seriesID int, params *ComicParams) *ComicIterator
+	CreatorsIter(seriesID int, params *CreatorParams) *CreatorIterator
+	CreatorsIterContext(ctx context.Context, seriesID int, params *CreatorParams) *CreatorIterator
+	EventsIter(seriesID int, params *EventParams) *EventIterator
+	EventsIterContext(ctx context.Context, seriesID int, params *EventParams) *EventIterator
+	StoriesIter(seriesID int, params *StoryParams) *StoryIterator
+	StoriesIterContext(ctx context.Context, seriesID int, params *StoryParams) *StoryIterator
+}
+
+// StoryAPI is the interface of a StoryService, for querying stories.
+type StoryAPI interface {
+	AllWrapped(params *StoryParams) (*StoryDataWrapper, *http.Response, error)
+	AllWrappedContext(ctx context.Context, params *StoryParams) (*StoryDataWrapper, *http.Response, error)
+	All(params *StoryParams) ([]Story, error)
+	AllContext(ctx context.Context, params *StoryParams) ([]Story, error)
+	GetWrapped(storyID int) (*StoryDataWrapper, *http.Response, error)
+	GetWrappedContext(ctx context.Context, storyID int) (*StoryDataWrapper, *http.Response, error)
+	Get(storyID int) (*Story, error)
+	GetContext(ctx context.Context, storyID int) (*Story, error)
+	GetMany(storyIDs []int, workers int) (map[int]*Story, map[int]error)
+	GetManyContext(ctx context.Context, storyIDs []int, workers int) (map[int]*Story, map[int]error)
+	CharactersWrapped(storyID int, params *CharacterParams) (*CharacterDataWrapper, *http.Response, error)
+	CharactersWrappedContext(ctx context.Context, storyID int, params *CharacterParams) (*CharacterDataWrapper, *http.Response, error)
+	Characters(storyID int, params *CharacterParams) ([]Character, error)
+	CharactersContext(ctx context.Context, storyID int, params *CharacterParams) ([]Character, error)
+	ComicsWrapped(storyID int, params *ComicParams) (*ComicDataWrapper, *http.Response, error)
+	ComicsWrappedContext(ctx context.Context, storyID int, params *ComicParams) (*ComicDataWrapper, *http.Response, error)
+	Comics(storyID int, params *ComicParams) ([]Comic, error)
+	ComicsContext(ctx context.Context, storyID int, params *ComicParams) ([]Comic, error)
+	CreatorsWrapped(storyID int, params *CreatorParams) (*CreatorDataWrapper, *http.Response, error)
+	CreatorsWrappedContext(ctx context.Context, storyID int, params *CreatorParams) (*CreatorDataWrapper, *http.Response, error)
+	Creators(storyID int, params *CreatorParams) ([]Creator, error)
+	CreatorsContext(ctx context.Context, storyID int, params *CreatorParams) ([]Creator, error)
+	EventsWrapped(eventID int, params *EventParams) (*EventDataWrapper, *http.Response, error)
+	EventsWrappedContext(ctx context.Context, eventID int, params *EventParams) (*EventDataWrapper, *http.Response, error)
+	Events(eventID int, params *EventParams) ([]Event, error)
+	EventsContext(ctx context.Context, eventID int, params *EventParams) ([]Event, error)
+	SeriesWrapped(seriesID int, params *SeriesParams) (*SeriesDataWrapper, *http.Response, error)
+	SeriesWrappedContext(ctx context.Context, seriesID int, params *SeriesParams) (*SeriesDataWrapper, *http.Response, error)
+	Series(seriesID int, params *SeriesParams) ([]Series, error)
+	SeriesContext(ctx context.Context, seriesID int, params *SeriesParams) ([]Series, error)
+	AllIter(params *StoryParams) *StoryIterator
+	AllIterContext(ctx context.Context, params *StoryParams) *StoryIterator
+	Walk(params *StoryParams, fn func(Story) error) error
+	WalkContext(ctx context.Context, params *StoryParams, fn func(Story) error) error
+	CharactersIter(storyID int, params *CharacterParams) *CharacterIterator
+	CharactersIterContext(ctx context.Context, storyID int, params *CharacterParams) *CharacterIterator
+	ComicsIter(storyID int, params *ComicParams) *ComicIterator
+	ComicsIterContext(ctx context.Context, storyID int, params *ComicParams) *ComicIterator
+	CreatorsIter(storyID int, params *CreatorParams) *CreatorIterator
+	CreatorsIterContext(ctx context.Context, storyID int, params *CreatorParams) *CreatorIterator
+	EventsIter(storyID int, params *EventParams) *EventIterator
+	EventsIterContext(ctx context.Context, storyID int, params *EventParams) *EventIterator
+	SeriesIter(storyID int, params *SeriesParams) *SeriesIterator
+	SeriesIterContext(ctx context.Context, storyID int, params *SeriesParams) *SeriesIterator
+}
@@ -1,6 +1,7 @@
 package marvel
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -23,107 +24,308 @@ func NewStoryService(sling *sling.Sling) *StoryService {
 // AllWrapped returns all stories that match the query parameters. The story
 // slice will be encapsulated by StoryDataContainer and StoryDataWrapper.
 func (sts *StoryService) AllWrapped(params *StoryParams) (*StoryDataWrapper, *http.Response, error) {
+	return sts.AllWrappedContext(context.Background(), params)
+}
+
+// AllWrappedContext is like AllWrapped, but the request is sent using ctx.
+func (sts *StoryService) AllWrappedContext(ctx context.Context, params *StoryParams) (*StoryDataWrapper, *http.Response, error) {
 	wrap := &StoryDataWrapper{}
-	resp, err := receiveWrapped(sts.sling, "../stories", wrap, params)
+	resp, err := receiveWrapped(ctx, sts.sling, "../stories", wrap, params)
 	return wrap, resp, err
 }
 
 // All returns all stories that match the query parameters.
 func (sts *StoryService) All(params *StoryParams) ([]Story, error) {
-	wrap, _, err := sts.AllWrapped(params)
-	return wrap.Data.Results, err
+	return sts.AllContext(context.Background(), params)
+}
+
+// AllContext is like All, but the request is sent using ctx.
+func (sts *StoryService) AllContext(ctx context.Context, params *StoryParams) ([]Story, error) {
+	wrap, _, err := sts.AllWrappedContext(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
 }
 
 // GetWrapped returns the story associated with the given ID. The story
 // details will be encapsulated by StoryDataContainer and StoryDataWrapper.
 func (sts *StoryService) GetWrapped(storyID int) (*StoryDataWrapper, *http.Response, error) {
+	return sts.GetWrappedContext(context.Background(), storyID)
+}
+
+// GetWrappedContext is like GetWrapped, but the request is sent using ctx.
+func (sts *StoryService) GetWrappedContext(ctx context.Context, storyID int) (*StoryDataWrapper, *http.Response, error) {
 	wrap := &StoryDataWrapper{}
-	resp, err := receiveWrapped(sts.sling, fmt.Sprintf("%d", storyID), wrap, nil)
+	resp, err := receiveWrapped(ctx, sts.sling, fmt.Sprintf("%d", storyID), wrap, nil)
 	return wrap, resp, err
 }
 
-// Get returns the story associated with the given ID.
+// Get returns the story associated with the given ID. A NotFoundError is
+// returned if there is no such story.
 func (sts *StoryService) Get(storyID int) (*Story, error) {
-	wrap, _, err := sts.GetWrapped(storyID)
-	if err != nil {
+	return sts.GetContext(context.Background(), storyID)
+}
+
+// GetContext is like Get, but the request is sent using ctx.
+func (sts *StoryService) GetContext(ctx context.Context, storyID int) (*Story, error) {
+	wrap, _, err := sts.GetWrappedContext(ctx, storyID)
+	if err = getError(StoriesResource, storyID, err, len(wrap.Data.Results)); err != nil {
 		return nil, err
 	}
 	return &wrap.Data.Results[0], nil
 }
 
+// GetMany returns the stories associated with the given IDs, keyed by ID. The
+// stories are requested concurrently, at most workers at a time, and any errors
+// are returned keyed by the ID that caused them. A workers value less than 1 is
+// treated as 1.
+func (sts *StoryService) GetMany(storyIDs []int, workers int) (map[int]*Story, map[int]error) {
+	return sts.GetManyContext(context.Background(), storyIDs, workers)
+}
+
+// GetManyContext is like GetMany, but the requests are sent using ctx.
+func (sts *StoryService) GetManyContext(ctx context.Context, storyIDs []int, workers int) (map[int]*Story, map[int]error) {
+	values, errs := getMany(ctx, storyIDs, workers, func(ctx context.Context, id int) (interface{}, error) {
+		return sts.GetContext(ctx, id)
+	})
+	stories := make(map[int]*Story, len(values))
+	for id, v := range values {
+		stories[id] = v.(*Story)
+	}
+	return stories, errs
+}
+
 // CharactersWrapped returns all characters involving the given story and match the
 // query parameters. The character slice will be encapsulated by CharacterDataContainer
 // and CharacterDataWrapper.
 func (sts *StoryService) CharactersWrapped(storyID int, params *CharacterParams) (*CharacterDataWrapper, *http.Response, error) {
+	return sts.CharactersWrappedContext(context.Background(), storyID, params)
+}
+
+// CharactersWrappedContext is like CharactersWrapped, but the request is sent using ctx.
+func (sts *StoryService) CharactersWrappedContext(ctx context.Context, storyID int, params *CharacterParams) (*CharacterDataWrapper, *http.Response, error) {
 	wrap := &CharacterDataWrapper{}
-	resp, err := receiveWrapped(sts.sling, fmt.Sprintf("%d/characters", storyID), wrap, params)
+	resp, err := receiveWrapped(ctx, sts.sling, fmt.Sprintf("%d/characters", storyID), wrap, params)
 	return wrap, resp, err
 }
 
 // Characters returns all characters involving the given story and match the query parameters.
 func (sts *StoryService) Characters(storyID int, params *CharacterParams) ([]Character, error) {
-	wrap, _, err := sts.CharactersWrapped(storyID, params)
-	return wrap.Data.Results, err
+	return sts.CharactersContext(context.Background(), storyID, params)
+}
+
+// CharactersContext is like Characters, but the request is sent using ctx.
+func (sts *StoryService) CharactersContext(ctx context.Context, storyID int, params *CharacterParams) ([]Character, error) {
+	wrap, _, err := sts.CharactersWrappedContext(ctx, storyID, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
 }
 
 // ComicsWrapped returns all comics involving the given story and match the
 // query parameters. The comic slice will be encapsulated by ComicDataContainer
 // and ComicDataWrapper.
 func (sts *StoryService) ComicsWrapped(storyID int, params *ComicParams) (*ComicDataWrapper, *http.Response, error) {
+	return sts.ComicsWrappedContext(context.Background(), storyID, params)
+}
+
+// ComicsWrappedContext is like ComicsWrapped, but the request is sent using ctx.
+func (sts *StoryService) ComicsWrappedContext(ctx context.Context, storyID int, params *ComicParams) (*ComicDataWrapper, *http.Response, error) {
 	wrap := &ComicDataWrapper{}
-	resp, err := receiveWrapped(sts.sling, fmt.Sprintf("%d/comics", storyID), wrap, params)
+	resp, err := receiveWrapped(ctx, sts.sling, fmt.Sprintf("%d/comics", storyID), wrap, params)
 	return wrap, resp, err
 }
 
 // Comics returns all comics involving the given story and match the query parameters.
 func (sts *StoryService) Comics(storyID int, params *ComicParams) ([]Comic, error) {
-	wrap, _, err := sts.ComicsWrapped(storyID, params)
-	return wrap.Data.Results, err
+	return sts.ComicsContext(context.Background(), storyID, params)
+}
+
+// ComicsContext is like Comics, but the request is sent using ctx.
+func (sts *StoryService) ComicsContext(ctx context.Context, storyID int, params *ComicParams) ([]Comic, error) {
+	wrap, _, err := sts.ComicsWrappedContext(ctx, storyID, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
 }
 
 // CreatorsWrapped returns all creators involving the given story and match the
 // query parameters. The creator slice will be encapsulated by CreatorDataContainer
 // and CreatorDataWrapper.
 func (sts *StoryService) CreatorsWrapped(storyID int, params *CreatorParams) (*CreatorDataWrapper, *http.Response, error) {
+	return sts.CreatorsWrappedContext(context.Background(), storyID, params)
+}
+
+// CreatorsWrappedContext is like CreatorsWrapped, but the request is sent using ctx.
+func (sts *StoryService) CreatorsWrappedContext(ctx context.Context, storyID int, params *CreatorParams) (*CreatorDataWrapper, *http.Response, error) {
 	wrap := &CreatorDataWrapper{}
-	resp, err := receiveWrapped(sts.sling, fmt.Sprintf("%d/creators", storyID), wrap, params)
+	resp, err := receiveWrapped(ctx, sts.sling, fmt.Sprintf("%d/creators", storyID), wrap, params)
 	return wrap, resp, err
 }
 
 // Creators returns all creators involving the given story and match the query parameters.
 func (sts *StoryService) Creators(storyID int, params *CreatorParams) ([]Creator, error) {
-	wrap, _, err := sts.CreatorsWrapped(storyID, params)
-	return wrap.Data.Results, err
+	return sts.CreatorsContext(context.Background(), storyID, params)
+}
+
+// CreatorsContext is like Creators, but the request is sent using ctx.
+func (sts *StoryService) CreatorsContext(ctx context.Context, storyID int, params *CreatorParams) ([]Creator, error) {
+	wrap, _, err := sts.CreatorsWrappedContext(ctx, storyID, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
 }
 
 // EventsWrapped returns all events involving the given story and match the
 // query parameters. The event slice will be encapsulated by EventDataContainer
 // and EventDataWrapper.
 func (sts *StoryService) EventsWrapped(eventID int, params *EventParams) (*EventDataWrapper, *http.Response, error) {
+	return sts.EventsWrappedContext(context.Background(), eventID, params)
+}
+
+// EventsWrappedContext is like EventsWrapped, but the request is sent using ctx.
+func (sts *StoryService) EventsWrappedContext(ctx context.Context, eventID int, params *EventParams) (*EventDataWrapper, *http.Response, error) {
 	wrap := &EventDataWrapper{}
-	resp, err := receiveWrapped(sts.sling, fmt.Sprintf("%d/events", eventID), wrap, params)
+	resp, err := receiveWrapped(ctx, sts.sling, fmt.Sprintf("%d/events", eventID), wrap, params)
 	return wrap, resp, err
 }
 
 // Events returns all events involving the given series and match the query parameters.
 func (sts *StoryService) Events(eventID int, params *EventParams) ([]Event, error) {
-	wrap, _, err := sts.EventsWrapped(eventID, params)
-	return wrap.Data.Results, err
+	return sts.EventsContext(context.Background(), eventID, params)
+}
+
+// EventsContext is like Events, but the request is sent using ctx.
+func (sts *StoryService) EventsContext(ctx context.Context, eventID int, params *EventParams) ([]Event, error) {
+	wrap, _, err := sts.EventsWrappedContext(ctx, eventID, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
 }
 
 // SeriesWrapped returns all series involving the given series and match the
 // query parameters. The series slice will be encapsulated by SeriesDataContainer
 // and SeriesDataWrapper.
 func (sts *StoryService) SeriesWrapped(seriesID int, params *SeriesParams) (*SeriesDataWrapper, *http.Response, error) {
+	return sts.SeriesWrappedContext(context.Background(), seriesID, params)
+}
+
+// SeriesWrappedContext is like SeriesWrapped, but the request is sent using ctx.
+func (sts *StoryService) SeriesWrappedContext(ctx context.Context, seriesID int, params *SeriesParams) (*SeriesDataWrapper, *http.Response, error) {
 	wrap := &SeriesDataWrapper{}
-	resp, err := receiveWrapped(sts.sling, fmt.Sprintf("%d/series", seriesID), wrap, params)
+	resp, err := receiveWrapped(ctx, sts.sling, fmt.Sprintf("%d/series", seriesID), wrap, params)
 	return wrap, resp, err
 }
 
 // Series returns all series involving the given series and match the query parameters.
 func (sts *StoryService) Series(seriesID int, params *SeriesParams) ([]Series, error) {
-	wrap, _, err := sts.SeriesWrapped(seriesID, params)
-	return wrap.Data.Results, err
+	return sts.SeriesContext(context.Background(), seriesID, params)
+}
+
+// SeriesContext is like Series, but the request is sent using ctx.
+func (sts *StoryService) SeriesContext(ctx context.Context, seriesID int, params *SeriesParams) ([]Series, error) {
+	wrap, _, err := sts.SeriesWrappedContext(ctx, seriesID, params)
+	if err != nil {
+		return nil, err
+	}
+	return wrap.Data.Results, nil
+}
+
+// AllIter returns a StoryIterator over all stories that match the query
+// parameters, regardless of how many pages of results there are.
+func (sts *StoryService) AllIter(params *StoryParams) *StoryIterator {
+	return sts.AllIterContext(context.Background(), params)
+}
+
+// AllIterContext is like AllIter, but the requests are sent using ctx.
+func (sts *StoryService) AllIterContext(ctx context.Context, params *StoryParams) *StoryIterator {
+	return NewStoryIterator(ctx, params, sts.AllWrappedContext)
+}
+
+// Walk calls fn for all stories that match the query parameters, regardless
+// of how many pages of results there are.
+func (sts *StoryService) Walk(params *StoryParams, fn func(Story) error) error {
+	return sts.WalkContext(context.Background(), params, fn)
+}
+
+// WalkContext is like Walk, but the requests are sent using ctx.
+func (sts *StoryService) WalkContext(ctx context.Context, params *StoryParams, fn func(Story) error) error {
+	return sts.AllIterContext(ctx, params).Walk(fn)
+}
+
+// CharactersIter returns a CharacterIterator over all characters involving the given
+// story and match the query parameters, regardless of how many pages of
+// results there are.
+func (sts *StoryService) CharactersIter(storyID int, params *CharacterParams) *CharacterIterator {
+	return sts.CharactersIterContext(context.Background(), storyID, params)
+}
+
+// CharactersIterContext is like CharactersIter, but the requests are sent using ctx.
+func (sts *StoryService) CharactersIterContext(ctx context.Context, storyID int, params *CharacterParams) *CharacterIterator {
+	return NewCharacterIterator(ctx, params, func(ctx context.Context, params *CharacterParams) (*CharacterDataWrapper, *http.Response, error) {
+		return sts.CharactersWrappedContext(ctx, storyID, params)
+	})
+}
+
+// ComicsIter returns a ComicIterator over all comics involving the given
+// story and match the query parameters, regardless of how many pages of
+// results there are.
+func (sts *StoryService) ComicsIter(storyID int, params *ComicParams) *ComicIterator {
+	return sts.ComicsIterContext(context.Background(), storyID, params)
+}
+
+// ComicsIterContext is like ComicsIter, but the requests are sent using ctx.
+func (sts *StoryService) ComicsIterContext(ctx context.Context, storyID int, params *ComicParams) *ComicIterator {
+	return NewComicIterator(ctx, params, func(ctx context.Context, params *ComicParams) (*ComicDataWrapper, *http.Response, error) {
+		return sts.ComicsWrappedContext(ctx, storyID, params)
+	})
+}
+
+// CreatorsIter returns a CreatorIterator over all creators involving the given
+// story and match the query parameters, regardless of how many pages of
+// results there are.
+func (sts *StoryService) CreatorsIter(storyID int, params *CreatorParams) *CreatorIterator {
+	return sts.CreatorsIterContext(context.Background(), storyID, params)
+}
+
+// CreatorsIterContext is like CreatorsIter, but the requests are sent using ctx.
+func (sts *StoryService) CreatorsIterContext(ctx context.Context, storyID int, params *CreatorParams) *CreatorIterator {
+	return NewCreatorIterator(ctx, params, func(ctx context.Context, params *CreatorParams) (*CreatorDataWrapper, *http.Response, error) {
+		return sts.CreatorsWrappedContext(ctx, storyID, params)
+	})
+}
+
+// EventsIter returns an EventIterator over all events involving the given
+// story and match the query parameters, regardless of how many pages of
+// results there are.
+func (sts *StoryService) EventsIter(storyID int, params *EventParams) *EventIterator {
+	return sts.EventsIterContext(context.Background(), storyID, params)
+}
+
+// EventsIterContext is like EventsIter, but the requests are sent using ctx.
+func (sts *StoryService) EventsIterContext(ctx context.Context, storyID int, params *EventParams) *EventIterator {
+	return NewEventIterator(ctx, params, func(ctx context.Context, params *EventParams) (*EventDataWrapper, *http.Response, error) {
+		return sts.EventsWrappedContext(ctx, storyID, params)
+	})
+}
+
+// SeriesIter returns a SeriesIterator over all series involving the given
+// story and match the query parameters, regardless of how many pages of
+// results there are.
+func (sts *StoryService) SeriesIter(storyID int, params *SeriesParams) *SeriesIterator {
+	return sts.SeriesIterContext(context.Background(), storyID, params)
+}
+
+// SeriesIterContext is like SeriesIter, but the requests are sent using ctx.
+func (sts *StoryService) SeriesIterContext(ctx context.Context, storyID int, params *SeriesParams) *SeriesIterator {
+	return NewSeriesIterator(ctx, params, func(ctx context.Context, params *SeriesParams) (*SeriesDataWrapper, *http.Response, error) {
+		return sts.SeriesWrappedContext(ctx, storyID, params)
+	})
 }
 
 // StoryDataWrapper provides story wrapper information returned by the API.
@@ -158,15 +360,50 @@ type Story struct {
 // StoryParams are optional parameters to narrow the story results returned
 // by the API, as well as specify the number and order.
 type StoryParams struct {
-	ModifiedSince time.Time `url:"modifiedSince,omitempty"`
-	Comics        []int     `url:"comics,omitempty"`
-	Series        []int     `url:"series,omitempty"`
-	Events        []int     `url:"events,omitempty"`
-	Creators      []int     `url:"creators,omitempty"`
-	Characters    []int     `url:"characters,omitempty"`
-	OrderBy       string    `url:"orderBy,omitempty"`
-	Limit         int       `url:"limit,omitempty"`
-	Offset        int       `url:"offset,omitempty"`
+	ModifiedSince time.Time    `url:"modifiedSince,omitempty" layout:"2006-01-02T15:04:05-0700"`
+	Comics        IDList       `url:"comics,omitempty"`
+	Series        IDList       `url:"series,omitempty"`
+	Events        IDList       `url:"events,omitempty"`
+	Creators      IDList       `url:"creators,omitempty"`
+	Characters    IDList       `url:"characters,omitempty"`
+	OrderBy       StoryOrderBy `url:"orderBy,omitempty"`
+	Limit         int          `url:"limit,omitempty"`
+	Offset        int          `url:"offset,omitempty"`
+}
+
+// StoryOrderBy is the order of a list of stories. Orders may be joined with
+// commas, in decreasing priority, e.g., "id,-modified".
+type StoryOrderBy string
+
+// The orders in which stories can be listed. The Desc variants sort in
+// descending order.
+const (
+	StoryOrderByID           StoryOrderBy = "id"
+	StoryOrderByIDDesc       StoryOrderBy = "-id"
+	StoryOrderByModified     StoryOrderBy = "modified"
+	StoryOrderByModifiedDesc StoryOrderBy = "-modified"
+)
+
+// valid reports whether the API accepts the order.
+func (o StoryOrderBy) valid() bool {
+	return validOrder(string(o), "id", "modified")
+}
+
+// Validate returns a *ValidationError listing each of the parameters the API
+// would reject. It is called before each request is sent with the parameters.
+func (p *StoryParams) Validate() error {
+	if p == nil {
+		return nil
+	}
+	v := newValidation("StoryParams")
+	v.ids("Comics", p.Comics)
+	v.ids("Series", p.Series)
+	v.ids("Events", p.Events)
+	v.ids("Creators", p.Creators)
+	v.ids("Characters", p.Characters)
+	v.enum(p.OrderBy.valid(), "OrderBy", string(p.OrderBy))
+	v.page(p.Limit, p.Offset)
+	return v.err()
 }
 
 // StoryList provides stories related to the parent entity.
@@ -0,0 +1,318 @@
+package marvel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// syncResources are the resources synced by default, in order.
+var syncResources = []Resource{
+	CharactersResource, ComicsResource, CreatorsResource,
+	EventsResource, SeriesResource, StoriesResource,
+}
+
+// SyncCheckpoint records a Syncer's progress through a resource.
+type SyncCheckpoint struct {
+	// Modified is the newest Modified time of the resource's entities as of the
+	// last completed sync. The next sync requests only those modified since.
+	Modified time.Time `json:"modified"`
+	// Offset is the number of entities stored by an incomplete sync, which is
+	// resumed from there. It is zero once the sync completes.
+	Offset int `json:"offset"`
+	// Newest is the newest Modified time of the entities stored by an
+	// incomplete sync.
+	Newest time.Time `json:"newest"`
+}
+
+// SyncStore is the interface for holding the entities pulled by a Syncer, and
+// its checkpoints.
+type SyncStore interface {
+	// Put stores an entity of the resource, i.e., a Character, Comic, Creator,
+	// Event, Series or Story, replacing any stored with the same ID.
+	Put(resource Resource, id int, entity interface{}) error
+	// Checkpoint returns the resource's saved checkpoint, or a zero
+	// SyncCheckpoint if none has been saved.
+	Checkpoint(resource Resource) (SyncCheckpoint, error)
+	// SaveCheckpoint saves the resource's checkpoint, once the entities it
+	// covers have been stored.
+	SaveCheckpoint(resource Resource, cp SyncCheckpoint) error
+}
+
+// SyncProgress reports a Syncer's progress through a resource.
+type SyncProgress struct {
+	Resource Resource
+	// Synced is the number of entities stored so far, including any stored
+	// before the sync was resumed.
+	Synced int
+	// Total is the number of entities to sync, as last reported by the API.
+	Total int
+	// Done is set once the resource has been synced.
+	Done bool
+}
+
+// Syncer mirrors the API's entities into a SyncStore. The first sync of a
+// resource pulls all of its entities. Later syncs pull only those modified
+// since the newest already stored.
+//
+// Entities are listed oldest modified first, a page at a time, and the
+// resource's checkpoint is saved after each page, so an interrupted sync, e.g.,
+// one canceled or which ran out of daily calls, resumes from the last page
+// stored. Requests are sent one at a time through the Client, subject to its
+// RateLimiter and RetryPolicy.
+//
+// An entity modified during a sync moves to the end of the listing, which
+// can shift another into a page already stored, to be missed until that
+// entity is next modified. Remove a resource's checkpoint to pull it in full.
+type Syncer struct {
+	// Resources are the resources synced, in order. If empty, all of them are.
+	Resources []Resource
+	// Progress, if not nil, is called after each page of entities is stored,
+	// and once each resource is done.
+	Progress func(SyncProgress)
+
+	client *Client
+	store  SyncStore
+}
+
+// NewSyncer returns a Syncer which pulls entities with client into store.
+func NewSyncer(client *Client, store SyncStore) *Syncer {
+	return &Syncer{client: client, store: store}
+}
+
+// Sync pulls the new and modified entities of each of the Syncer's resources
+// into its store.
+func (s *Syncer) Sync() error {
+	return s.SyncContext(context.Background())
+}
+
+// SyncContext is like Sync, but the requests are sent using ctx.
+func (s *Syncer) SyncContext(ctx context.Context) error {
+	resources := s.Resources
+	if len(resources) == 0 {
+		resources = syncResources
+	}
+	for _, resource := range resources {
+		if err := s.sync(ctx, resource); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sync pulls the entities of a resource, resuming from its checkpoint.
+func (s *Syncer) sync(ctx context.Context, resource Resource) error {
+	cp, err := s.store.Checkpoint(resource)
+	if err != nil {
+		return err
+	}
+	for {
+		p, err := s.page(ctx, resource, cp.Modified, cp.Offset)
+		if err != nil {
+			return err
+		}
+		for _, e := range p.entities {
+			if err := s.store.Put(resource, e.id, e.value); err != nil {
+				return err
+			}
+			if e.modified.After(cp.Newest) {
+				cp.Newest = e.modified
+			}
+		}
+		cp.Offset += len(p.entities)
+
+		done := len(p.entities) == 0 || cp.Offset >= p.total
+		if done {
+			if cp.Newest.After(cp.Modified) {
+				cp.Modified = cp.Newest
+			}
+			cp.Offset, cp.Newest = 0, time.Time{}
+		}
+		if err := s.store.SaveCheckpoint(resource, cp); err != nil {
+			return err
+		}
+		if s.Progress != nil {
+			synced := cp.Offset
+			if done {
+				synced = p.total
+			}
+			s.Progress(SyncProgress{Resource: resource, Synced: synced, Total: p.total, Done: done})
+		}
+		if done {
+			return nil
+		}
+	}
+}
+
+// syncEntity is an entity pulled by a Syncer.
+type syncEntity struct {
+	id       int
+	modified time.Time
+	value    interface{}
+}
+
+// syncPage is a page of entities pulled by a Syncer, and the total number of
+// entities to sync.
+type syncPage struct {
+	entities []syncEntity
+	total    int
+}
+
+func (p *syncPage) add(id int, modified Time, value interface{}) {
+	p.entities = append(p.entities, syncEntity{id: id, modified: modified.Time, value: value})
+}
+
+// page requests a page of a resource's entities modified since the given time,
+// oldest modified first.
+func (s *Syncer) page(ctx context.Context, resource Resource, since time.Time, offset int) (*syncPage, error) {
+	p := &syncPage{}
+	switch resource {
+	case CharactersResource:
+		params := &CharacterParams{ModifiedSince: since, OrderBy: CharacterOrderByModified, Limit: maxLimit, Offset: offset}
+		wrap, _, err := s.client.Characters.AllWrappedContext(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		p.total = wrap.Data.Total
+		for _, ch := range wrap.Data.Results {
+			p.add(ch.ID, ch.Modified, ch)
+		}
+	case ComicsResource:
+		params := &ComicParams{ModifiedSince: since, OrderBy: ComicOrderByModified, Limit: maxLimit, Offset: offset}
+		wrap, _, err := s.client.Comics.AllWrappedContext(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		p.total = wrap.Data.Total
+		for _, co := range wrap.Data.Results {
+			p.add(co.ID, co.Modified, co)
+		}
+	case CreatorsResource:
+		params := &CreatorParams{ModifiedSince: since, OrderBy: CreatorOrderByModified, Limit: maxLimit, Offset: offset}
+		wrap, _, err := s.client.Creators.AllWrappedContext(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		p.total = wrap.Data.Total
+		for _, ctr := range wrap.Data.Results {
+			p.add(ctr.ID, ctr.Modified, ctr)
+		}
+	case EventsResource:
+		params := &EventParams{ModifiedSince: since, OrderBy: EventOrderByModified, Limit: maxLimit, Offset: offset}
+		wrap, _, err := s.client.Events.AllWrappedContext(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		p.total = wrap.Data.Total
+		for _, ev := range wrap.Data.Results {
+			p.add(ev.ID, ev.Modified, ev)
+		}
+	case SeriesResource:
+		params := &SeriesParams{ModifiedSince: since, OrderBy: SeriesOrderByModified, Limit: maxLimit, Offset: offset}
+		wrap, _, err := s.client.Series.AllWrappedContext(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		p.total = wrap.Data.Total
+		for _, sr := range wrap.Data.Results {
+			p.add(sr.ID, sr.Modified, sr)
+		}
+	case StoriesResource:
+		params := &StoryParams{ModifiedSince: since, OrderBy: StoryOrderByModified, Limit: maxLimit, Offset: offset}
+		wrap, _, err := s.client.Stories.AllWrappedContext(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		p.total = wrap.Data.Total
+		for _, st := range wrap.Data.Results {
+			p.add(st.ID, st.Modified, st)
+		}
+	default:
+		return nil, fmt.Errorf("marvel: cannot sync unknown resource %q", resource)
+	}
+	return p, nil
+}
+
+// FileSyncStore is a SyncStore which writes each entity as a JSON file,
+// named by its ID, in a directory per resource, alongside the resource's
+// checkpoint.
+type FileSyncStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewFileSyncStore returns a FileSyncStore storing entities in dir, which is
+// created if necessary.
+func NewFileSyncStore(dir string) (*FileSyncStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileSyncStore{dir: dir}, nil
+}
+
+// Put implements the SyncStore interface.
+func (fs *FileSyncStore) Put(resource Resource, id int, entity interface{}) error {
+	b, err := json.Marshal(entity)
+	if err != nil {
+		return err
+	}
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if err := os.MkdirAll(filepath.Join(fs.dir, string(resource)), 0755); err != nil {
+		return err
+	}
+	return writeFileAtomic(fs.path(resource, strconv.Itoa(id)), b)
+}
+
+// Get reads the stored entity of the resource with the given ID into v,
+// returning an error satisfying errors.Is(err, os.ErrNotExist) if there is
+// none.
+func (fs *FileSyncStore) Get(resource Resource, id int, v interface{}) error {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	b, err := ioutil.ReadFile(fs.path(resource, strconv.Itoa(id)))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// Checkpoint implements the SyncStore interface.
+func (fs *FileSyncStore) Checkpoint(resource Resource) (SyncCheckpoint, error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	var cp SyncCheckpoint
+	b, err := ioutil.ReadFile(fs.path(resource, "checkpoint"))
+	if errors.Is(err, os.ErrNotExist) {
+		return cp, nil
+	} else if err != nil {
+		return cp, err
+	}
+	return cp, json.Unmarshal(b, &cp)
+}
+
+// SaveCheckpoint implements the SyncStore interface.
+func (fs *FileSyncStore) SaveCheckpoint(resource Resource, cp SyncCheckpoint) error {
+	b, err := json.Marshal(&cp)
+	if err != nil {
+		return err
+	}
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if err := os.MkdirAll(filepath.Join(fs.dir, string(resource)), 0755); err != nil {
+		return err
+	}
+	return writeFileAtomic(fs.path(resource, "checkpoint"), b)
+}
+
+// path returns the path of a resource's file with the given name.
+func (fs *FileSyncStore) path(resource Resource, name string) string {
+	return filepath.Join(fs.dir, string(resource), name+".json")
+}
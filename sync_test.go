@@ -0,0 +1,112 @@
+package marvel_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dustinrc/marvel"
+	"github.com/dustinrc/marvel/marveltest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncer(t *testing.T) {
+	srv := marveltest.NewServer()
+	defer srv.Close()
+	base := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+	modified := func(day int) marvel.Time {
+		return marvel.Time{Time: base.AddDate(0, 0, day)}
+	}
+	for id := 1; id <= 250; id++ {
+		srv.Store.AddComics(marvel.Comic{ID: id, Title: fmt.Sprintf("Comic %d", id), Modified: modified(id)})
+	}
+	srv.Store.AddCharacters(
+		marvel.Character{ID: 1, Name: "Spider-Man", Modified: modified(3)},
+		marvel.Character{ID: 2, Name: "Iron Man", Modified: modified(1)},
+	)
+
+	store, err := marvel.NewFileSyncStore(t.TempDir())
+	assert.NoError(t, err)
+	var progress []marvel.SyncProgress
+	s := marvel.NewSyncer(srv.NewClient(marvel.WithRetry(nil)), store)
+	s.Resources = []marvel.Resource{marvel.ComicsResource, marvel.CharactersResource}
+
+	t.Run("interrupted sync is resumed", func(t *testing.T) {
+		srv.Fail(500, 1)
+		s.Progress = func(p marvel.SyncProgress) {
+			progress = append(progress, p)
+			if len(progress) == 2 {
+				srv.Fail(500, 1)
+			}
+		}
+		assert.Error(t, s.Sync())
+		assert.Empty(t, progress, "Progress reported for failed page")
+		assert.Error(t, s.Sync())
+		assert.Equal(t, []marvel.SyncProgress{
+			{Resource: marvel.ComicsResource, Synced: 100, Total: 250},
+			{Resource: marvel.ComicsResource, Synced: 200, Total: 250},
+		}, progress)
+		cp, err := store.Checkpoint(marvel.ComicsResource)
+		assert.NoError(t, err)
+		assert.Equal(t, marvel.SyncCheckpoint{Offset: 200, Newest: modified(200).Time}, cp)
+
+		progress = nil
+		s.Progress = func(p marvel.SyncProgress) { progress = append(progress, p) }
+		assert.NoError(t, s.Sync())
+		assert.Equal(t, []marvel.SyncProgress{
+			{Resource: marvel.ComicsResource, Synced: 250, Total: 250, Done: true},
+			{Resource: marvel.CharactersResource, Synced: 2, Total: 2, Done: true},
+		}, progress)
+	})
+	t.Run("entities and checkpoints are stored", func(t *testing.T) {
+		for id := 1; id <= 250; id++ {
+			var comic marvel.Comic
+			assert.NoError(t, store.Get(marvel.ComicsResource, id, &comic))
+			assert.Equal(t, fmt.Sprintf("Comic %d", id), comic.Title)
+			assert.True(t, modified(id).Equal(comic.Modified.Time))
+		}
+		var character marvel.Character
+		assert.NoError(t, store.Get(marvel.CharactersResource, 1, &character))
+		assert.Equal(t, "Spider-Man", character.Name)
+		assert.True(t, os.IsNotExist(store.Get(marvel.CharactersResource, 3, &character)))
+
+		cp, err := store.Checkpoint(marvel.ComicsResource)
+		assert.NoError(t, err)
+		assert.Equal(t, marvel.SyncCheckpoint{Modified: modified(250).Time}, cp)
+		cp, err = store.Checkpoint(marvel.CharactersResource)
+		assert.NoError(t, err)
+		assert.Equal(t, marvel.SyncCheckpoint{Modified: modified(3).Time}, cp)
+	})
+	t.Run("only modified entities are pulled again", func(t *testing.T) {
+		srv.Store.AddComics(
+			marvel.Comic{ID: 7, Title: "Comic 7, revised", Modified: modified(300)},
+			marvel.Comic{ID: 251, Title: "Comic 251", Modified: modified(301)},
+		)
+		progress = nil
+		assert.NoError(t, s.Sync())
+		assert.Equal(t, []marvel.SyncProgress{
+			{Resource: marvel.ComicsResource, Synced: 3, Total: 3, Done: true},
+			{Resource: marvel.CharactersResource, Synced: 1, Total: 1, Done: true},
+		}, progress)
+
+		var comic marvel.Comic
+		assert.NoError(t, store.Get(marvel.ComicsResource, 7, &comic))
+		assert.Equal(t, "Comic 7, revised", comic.Title)
+		assert.NoError(t, store.Get(marvel.ComicsResource, 251, &comic))
+		assert.Equal(t, "Comic 251", comic.Title)
+		cp, err := store.Checkpoint(marvel.ComicsResource)
+		assert.NoError(t, err)
+		assert.Equal(t, marvel.SyncCheckpoint{Modified: modified(301).Time}, cp)
+	})
+	t.Run("canceled sync", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.ErrorIs(t, s.SyncContext(ctx), context.Canceled)
+	})
+	t.Run("unknown resource", func(t *testing.T) {
+		s.Resources = []marvel.Resource{"heroes"}
+		assert.Error(t, s.Sync())
+	})
+}